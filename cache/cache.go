@@ -0,0 +1,369 @@
+// Package cache는 Redis를 캐시로 사용하는 캐시 어사이드(cache-aside) 패턴을 제공합니다
+//
+// 캐시 미스 시 Loader로 원본 저장소에서 값을 읽어 캐시에 저장하며,
+// 같은 키에 대한 동시 로드는 하나로 합쳐집니다(singleflight)
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"testcontainers-learning/redis"
+)
+
+// 기본 설정값
+const (
+	DefaultTTL         = 5 * time.Minute
+	DefaultNegativeTTL = 30 * time.Second
+)
+
+// ErrNotFound는 원본 저장소에 값이 없을 때 반환됩니다
+//
+// Loader가 반환한 에러가 WithNotFound 조건을 만족하면 ErrNotFound로 감싸 반환하고,
+// 네거티브 캐시에 기록된 키를 조회해도 ErrNotFound를 반환합니다
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionUnsupported는 Store가 VersionedStore를 구현하지 않아 SetVersion을 사용할 수 없을 때 반환됩니다
+var ErrVersionUnsupported = errors.New("store does not support versioned entries")
+
+// 캐시 항목 앞에 붙이는 헤더 (값 또는 네거티브 캐시 표시)
+//
+// 버전이 있는 값은 entryVersioned 뒤에 10진수 버전과 ':'를 붙인 뒤 값을 저장합니다
+const (
+	entryNegative  byte = 0x00
+	entryValue     byte = 0x01
+	entryVersioned byte = 0x02
+)
+
+// Loader는 캐시 미스 시 원본 저장소에서 값을 읽어옵니다
+type Loader[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// config는 Cache 설정입니다
+type config struct {
+	prefix      string
+	ttl         time.Duration
+	jitter      float64
+	negativeTTL time.Duration
+	codec       redis.Codec
+	isNotFound  func(error) bool
+}
+
+// Option은 Cache 동작을 설정합니다
+type Option func(*config)
+
+// WithPrefix는 캐시 키 접두사를 설정합니다 (키는 "prefix:key" 형식)
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// WithTTL은 캐시 항목의 TTL을 설정합니다 (기본값 DefaultTTL)
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.ttl = ttl
+	}
+}
+
+// WithJitter는 TTL에 최대 ttl*fraction만큼 무작위 시간을 더해
+// 동시에 저장된 항목이 한꺼번에 만료되지 않도록 합니다
+func WithJitter(fraction float64) Option {
+	return func(c *config) {
+		c.jitter = fraction
+	}
+}
+
+// WithNegativeTTL은 원본에 없는 키를 기억하는 시간을 설정합니다 (0이면 네거티브 캐시 비활성화)
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithCodec은 값 직렬화에 사용할 Codec을 설정합니다 (기본값 redis.JSONCodec)
+func WithCodec(codec redis.Codec) Option {
+	return func(c *config) {
+		c.codec = codec
+	}
+}
+
+// WithNotFound는 Loader 에러 중 "값 없음"으로 취급할 조건을 설정합니다
+//
+// 기본값은 errors.Is(err, ErrNotFound)입니다
+func WithNotFound(fn func(error) bool) Option {
+	return func(c *config) {
+		c.isNotFound = fn
+	}
+}
+
+// Cache는 Loader와 Store를 묶은 캐시 어사이드 캐시입니다
+type Cache[K comparable, V any] struct {
+	store  Store
+	loader Loader[K, V]
+	cfg    config
+	group  singleflight.Group
+
+	mu sync.Mutex
+	// loads는 로드 중인 키별 상태이며, 로드 중에 Invalidate되었는지 확인하는 데 사용합니다
+	loads map[string]*loadState
+}
+
+// loadState는 한 키에 대해 진행 중인 로드 상태입니다
+type loadState struct {
+	// gen은 로드 중에 Invalidate될 때마다 증가합니다
+	gen uint64
+	n   int
+}
+
+// New는 새로운 Cache를 생성합니다
+func New[K comparable, V any](store Store, loader Loader[K, V], opts ...Option) *Cache[K, V] {
+	cfg := config{
+		ttl:         DefaultTTL,
+		negativeTTL: DefaultNegativeTTL,
+		codec:       redis.JSONCodec{},
+		isNotFound: func(err error) bool {
+			return errors.Is(err, ErrNotFound)
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Cache[K, V]{store: store, loader: loader, cfg: cfg, loads: make(map[string]*loadState)}
+}
+
+// Get은 캐시에서 값을 조회하고, 없으면 Loader로 읽어 캐시에 저장합니다
+//
+// 캐시 조회에 실패하면 원본에서 읽어옵니다. 원본에 값이 없으면 ErrNotFound를 반환합니다
+func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	var zero V
+	storeKey := c.key(key)
+
+	data, err := c.store.Get(ctx, storeKey)
+	if err == nil {
+		value, err := c.decode(data)
+		if err == nil {
+			return value, nil
+		}
+		if errors.Is(err, ErrNotFound) {
+			return zero, fmt.Errorf("%s: %w", storeKey, ErrNotFound)
+		}
+		// 디코딩할 수 없는 항목은 원본에서 다시 읽어 덮어씀
+	}
+
+	// 같은 키의 동시 로드는 하나로 합치고, 먼저 호출한 쪽이 취소되어도 로드는 계속 진행
+	ch := c.group.DoChan(storeKey, func() (interface{}, error) {
+		return c.load(context.WithoutCancel(ctx), key, storeKey)
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(V), nil
+	}
+}
+
+// Set은 값을 캐시에 직접 저장합니다 (쓰기 후 캐시 갱신 등)
+func (c *Cache[K, V]) Set(ctx context.Context, key K, value V) error {
+	data, err := c.encode(value)
+	if err != nil {
+		return err
+	}
+	return c.store.Set(ctx, c.key(key), data, c.ttl())
+}
+
+// SetVersion은 캐시에 저장된 값보다 version이 클 때만 값을 저장하고 저장 여부를 반환합니다
+//
+// 여러 쓰기의 캐시 갱신이 커밋 순서와 다르게 도착해도 오래된 값이 새 값을 덮어쓰지 않습니다.
+// 버전이 있는 항목은 만료되거나 Invalidate될 때까지 Loader의 로드 결과로도 덮어쓰지 않습니다.
+// Store가 VersionedStore를 구현하지 않으면 ErrVersionUnsupported를 반환합니다
+func (c *Cache[K, V]) SetVersion(ctx context.Context, key K, value V, version int64) (bool, error) {
+	vs, ok := c.store.(VersionedStore)
+	if !ok {
+		return false, ErrVersionUnsupported
+	}
+
+	data, err := c.encode(value)
+	if err != nil {
+		return false, err
+	}
+	header := strconv.AppendInt([]byte{entryVersioned}, version, 10)
+	header = append(header, ':')
+	return vs.SetVersioned(ctx, c.key(key), append(header, data[1:]...), c.ttl())
+}
+
+// Invalidate는 캐시에서 키를 삭제합니다
+//
+// 진행 중인 로드 결과도 이후 호출과 공유하지 않으며, 무효화 전에 원본을 읽은 로드가
+// 이전 값을 캐시에 다시 저장하지 않도록 합니다. 다른 프로세스의 Cache에서 진행 중인
+// 로드에는 적용되지 않으므로 그 경우 이전 값은 최대 TTL 동안 남을 수 있습니다
+func (c *Cache[K, V]) Invalidate(ctx context.Context, keys ...K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	storeKeys := make([]string, len(keys))
+	c.mu.Lock()
+	for i, key := range keys {
+		storeKeys[i] = c.key(key)
+		c.group.Forget(storeKeys[i])
+		// 삭제보다 먼저 표시해야 로드가 저장한 값을 이 삭제나 로드 자신이 지움
+		if state, ok := c.loads[storeKeys[i]]; ok {
+			state.gen++
+		}
+	}
+	c.mu.Unlock()
+
+	return c.store.Delete(ctx, storeKeys...)
+}
+
+// load는 Loader로 값을 읽어 캐시에 저장합니다
+func (c *Cache[K, V]) load(ctx context.Context, key K, storeKey string) (V, error) {
+	gen := c.beginLoad(storeKey)
+	defer c.endLoad(storeKey)
+
+	value, err := c.loader(ctx, key)
+	if err != nil {
+		if !c.cfg.isNotFound(err) {
+			return value, err
+		}
+		if c.cfg.negativeTTL > 0 {
+			c.storeLoaded(ctx, storeKey, gen, []byte{entryNegative}, c.cfg.negativeTTL)
+		}
+		if errors.Is(err, ErrNotFound) {
+			return value, err
+		}
+		return value, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	if data, err := c.encode(value); err == nil {
+		c.storeLoaded(ctx, storeKey, gen, data, c.ttl())
+	}
+	return value, nil
+}
+
+// storeLoaded는 로드가 시작된 뒤 키가 무효화되지 않았을 때만 로드한 값을 저장합니다
+//
+// 저장하는 사이에 무효화되면 저장한 값을 다시 지웁니다. 캐시 저장 실패는 조회 결과에 영향을 주지 않습니다
+func (c *Cache[K, V]) storeLoaded(ctx context.Context, storeKey string, gen uint64, data []byte, ttl time.Duration) {
+	if c.generation(storeKey) != gen {
+		return
+	}
+	if vs, ok := c.store.(VersionedStore); ok {
+		// SetVersion으로 저장된 더 새로운 값은 덮어쓰지 않음
+		_, _ = vs.SetVersioned(ctx, storeKey, data, ttl)
+	} else {
+		_ = c.store.Set(ctx, storeKey, data, ttl)
+	}
+	if c.generation(storeKey) != gen {
+		_ = c.store.Delete(ctx, storeKey)
+	}
+}
+
+// beginLoad는 키의 로드 시작을 기록하고 현재 무효화 세대를 반환합니다
+func (c *Cache[K, V]) beginLoad(storeKey string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.loads[storeKey]
+	if !ok {
+		state = &loadState{}
+		c.loads[storeKey] = state
+	}
+	state.n++
+	return state.gen
+}
+
+// endLoad는 키의 로드 종료를 기록합니다
+func (c *Cache[K, V]) endLoad(storeKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.loads[storeKey]
+	if state.n--; state.n == 0 {
+		delete(c.loads, storeKey)
+	}
+}
+
+// generation은 키의 현재 무효화 세대를 반환합니다
+func (c *Cache[K, V]) generation(storeKey string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.loads[storeKey].gen
+}
+
+// key는 접두사를 붙인 캐시 키를 만듭니다
+func (c *Cache[K, V]) key(key K) string {
+	if c.cfg.prefix == "" {
+		return fmt.Sprint(key)
+	}
+	return fmt.Sprintf("%s:%v", c.cfg.prefix, key)
+}
+
+// ttl은 지터를 적용한 TTL을 반환합니다
+func (c *Cache[K, V]) ttl() time.Duration {
+	if c.cfg.jitter <= 0 || c.cfg.ttl <= 0 {
+		return c.cfg.ttl
+	}
+	return c.cfg.ttl + time.Duration(rand.Int64N(int64(float64(c.cfg.ttl)*c.cfg.jitter)+1))
+}
+
+func (c *Cache[K, V]) encode(value V) ([]byte, error) {
+	data, err := c.cfg.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{entryValue}, data...), nil
+}
+
+func (c *Cache[K, V]) decode(data []byte) (V, error) {
+	var value V
+	if len(data) == 0 {
+		return value, fmt.Errorf("empty cache entry")
+	}
+
+	switch data[0] {
+	case entryNegative:
+		return value, ErrNotFound
+	case entryValue:
+		err := c.cfg.codec.Unmarshal(data[1:], &value)
+		return value, err
+	case entryVersioned:
+		_, payload, ok := splitVersioned(data)
+		if !ok {
+			return value, fmt.Errorf("malformed versioned cache entry")
+		}
+		err := c.cfg.codec.Unmarshal(payload, &value)
+		return value, err
+	default:
+		return value, fmt.Errorf("unknown cache entry header 0x%02x", data[0])
+	}
+}
+
+// splitVersioned는 버전이 있는 항목을 버전과 값으로 나눕니다
+func splitVersioned(data []byte) (int64, []byte, bool) {
+	if len(data) == 0 || data[0] != entryVersioned {
+		return 0, nil, false
+	}
+	head, payload, ok := bytes.Cut(data[1:], []byte{':'})
+	if !ok {
+		return 0, nil, false
+	}
+	version, err := strconv.ParseInt(string(head), 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	return version, payload, true
+}
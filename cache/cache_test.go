@@ -0,0 +1,399 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"testcontainers-learning/redis"
+)
+
+// memoryStore는 테스트용 인메모리 Store입니다
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	ttls    map[string]time.Duration
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		entries: make(map[string][]byte),
+		ttls:    make(map[string]time.Duration),
+	}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	return value, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = value
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.entries, key)
+		delete(s.ttls, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) SetVersioned(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, _, ok := splitVersioned(s.entries[key]); ok {
+		if version, _, ok := splitVersioned(value); !ok || version <= current {
+			return false, nil
+		}
+	}
+	s.entries[key] = value
+	s.ttls[key] = ttl
+	return true, nil
+}
+
+func (s *memoryStore) ttl(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ttls[key]
+}
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// countingLoader는 호출 횟수를 세는 Loader를 만듭니다
+func countingLoader(users map[int]user, calls *atomic.Int32) Loader[int, user] {
+	return func(ctx context.Context, id int) (user, error) {
+		calls.Add(1)
+		u, ok := users[id]
+		if !ok {
+			return user{}, fmt.Errorf("user %d: %w", id, ErrNotFound)
+		}
+		return u, nil
+	}
+}
+
+func TestCacheAside(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	var calls atomic.Int32
+	users := map[int]user{1: {ID: 1, Name: "John"}}
+	c := New(store, countingLoader(users, &calls), WithPrefix("user"), WithTTL(time.Minute))
+
+	// 첫 조회는 캐시 미스
+	u, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "John", u.Name)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, time.Minute, store.ttl("user:1"))
+
+	// 두 번째 조회는 캐시 히트
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "John", u.Name)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// 무효화 후에는 원본에서 다시 읽음
+	users[1] = user{ID: 1, Name: "Johnny"}
+	require.NoError(t, c.Invalidate(ctx, 1))
+
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Johnny", u.Name)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Set으로 직접 갱신
+	require.NoError(t, c.Set(ctx, 1, user{ID: 1, Name: "Jonathan"}))
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Jonathan", u.Name)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCacheNegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	var calls atomic.Int32
+	c := New(store, countingLoader(map[int]user{}, &calls), WithPrefix("user"), WithNegativeTTL(10*time.Second))
+
+	// 없는 값도 네거티브 캐시에 기록되어 원본 조회는 한 번만 발생
+	for i := 0; i < 3; i++ {
+		_, err := c.Get(ctx, 404)
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 10*time.Second, store.ttl("user:404"))
+
+	// 네거티브 캐시를 끄면 매번 원본 조회
+	calls.Store(0)
+	c = New(newMemoryStore(), countingLoader(map[int]user{}, &calls), WithNegativeTTL(0))
+	for i := 0; i < 3; i++ {
+		_, err := c.Get(ctx, 404)
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCacheNotFoundMatcher(t *testing.T) {
+	ctx := context.Background()
+	errNoRows := errors.New("no rows")
+	errDown := errors.New("database down")
+
+	c := New(newMemoryStore(), func(ctx context.Context, id int) (user, error) {
+		if id == 1 {
+			return user{}, errNoRows
+		}
+		return user{}, errDown
+	}, WithNotFound(func(err error) bool { return errors.Is(err, errNoRows) }))
+
+	// 원본 에러도 함께 확인 가능
+	_, err := c.Get(ctx, 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, errNoRows)
+
+	// 그 밖의 에러는 그대로 전달되고 캐시하지 않음
+	_, err = c.Get(ctx, 2)
+	assert.ErrorIs(t, err, errDown)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := New(newMemoryStore(), func(ctx context.Context, id int) (user, error) {
+		calls.Add(1)
+		<-release
+		return user{ID: id, Name: "John"}, nil
+	})
+
+	const callers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, err := c.Get(ctx, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "John", u.Name)
+		}()
+	}
+
+	// 모든 호출이 로드를 기다리도록 잠시 대기한 뒤 해제
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCacheInvalidateDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	var mu sync.Mutex
+	name := "John"
+	loaded := make(chan struct{}, 1)
+	release := make(chan struct{})
+	c := New(store, func(ctx context.Context, id int) (user, error) {
+		mu.Lock()
+		u := user{ID: id, Name: name}
+		mu.Unlock()
+
+		// 원본을 읽은 뒤 저장하기 전까지 느리게 진행
+		select {
+		case loaded <- struct{}{}:
+			<-release
+		default:
+		}
+		return u, nil
+	}, WithPrefix("user"))
+
+	done := make(chan user)
+	go func() {
+		u, err := c.Get(ctx, 1)
+		assert.NoError(t, err)
+		done <- u
+	}()
+	<-loaded
+
+	// 로드가 이전 값을 읽은 뒤 원본이 바뀌고 무효화됨
+	mu.Lock()
+	name = "Johnny"
+	mu.Unlock()
+	require.NoError(t, c.Invalidate(ctx, 1))
+
+	close(release)
+	assert.Equal(t, "John", (<-done).Name)
+
+	// 무효화 전에 읽은 값은 캐시에 다시 저장되지 않아야 함
+	_, err := store.Get(ctx, "user:1")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	u, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Johnny", u.Name)
+	assert.Empty(t, c.loads)
+}
+
+func TestCacheSetVersion(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	var calls atomic.Int32
+	users := map[int]user{1: {ID: 1, Name: "John"}}
+	c := New(store, countingLoader(users, &calls), WithPrefix("user"))
+
+	// 더 높은 버전만 저장
+	stored, err := c.SetVersion(ctx, 1, user{ID: 1, Name: "v2"}, 2)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	stored, err = c.SetVersion(ctx, 1, user{ID: 1, Name: "v1"}, 1)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	u, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", u.Name)
+	assert.Zero(t, calls.Load())
+
+	// 로드 결과는 버전이 있는 항목을 덮어쓰지 않음
+	gen := c.beginLoad("user:1")
+	c.storeLoaded(ctx, "user:1", gen, append([]byte{entryValue}, `{"id":1,"name":"stale"}`...), time.Minute)
+	c.endLoad("user:1")
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", u.Name)
+
+	// 무효화 후에는 다시 로드
+	require.NoError(t, c.Invalidate(ctx, 1))
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "John", u.Name)
+
+	// VersionedStore가 아닌 Store
+	plain := New(struct{ Store }{newMemoryStore()}, countingLoader(users, &calls))
+	_, err = plain.SetVersion(ctx, 1, user{ID: 1}, 1)
+	assert.ErrorIs(t, err, ErrVersionUnsupported)
+}
+
+func TestCacheGetCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	c := New(newMemoryStore(), func(ctx context.Context, id int) (user, error) {
+		<-release
+		return user{ID: id}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCacheTTLJitter(t *testing.T) {
+	c := New[int, user](newMemoryStore(), nil, WithTTL(time.Minute), WithJitter(0.2))
+
+	// TTL은 [ttl, ttl*1.2] 범위에서 분산됨
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		ttl := c.ttl()
+		assert.GreaterOrEqual(t, ttl, time.Minute)
+		assert.LessOrEqual(t, ttl, 72*time.Second)
+		seen[ttl] = true
+	}
+	assert.Greater(t, len(seen), 1)
+}
+
+func TestCacheRedisStore(t *testing.T) {
+	ctx := context.Background()
+
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	require.NoError(t, err)
+	defer testcontainers.TerminateContainer(redisContainer)
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	require.NoError(t, err)
+
+	client := redis.NewClient(endpoint)
+	defer client.Close()
+
+	var calls atomic.Int32
+	users := map[int]user{1: {ID: 1, Name: "John"}}
+	c := New(NewRedisStore(client), countingLoader(users, &calls),
+		WithPrefix("user"),
+		WithTTL(time.Minute),
+		WithJitter(0.1),
+		WithCodec(redis.MsgpackCodec{}),
+	)
+
+	u, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "John", u.Name)
+
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "John", u.Name)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// 네거티브 캐시도 Redis에 저장됨
+	_, err = c.Get(ctx, 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+	count, err := client.Exists(ctx, "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// 버전 비교는 Lua 스크립트로 원자적으로 수행
+	stored, err := c.SetVersion(ctx, 1, user{ID: 1, Name: "v2"}, 2)
+	require.NoError(t, err)
+	assert.True(t, stored)
+	stored, err = c.SetVersion(ctx, 1, user{ID: 1, Name: "v1"}, 1)
+	require.NoError(t, err)
+	assert.False(t, stored)
+	stored, err = c.SetVersion(ctx, 1, user{ID: 1, Name: "v10"}, 10)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	u, err = c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v10", u.Name)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// 무효화하면 Redis에서 삭제
+	require.NoError(t, c.Invalidate(ctx, 1, 2))
+	count, err = client.Exists(ctx, "user:1", "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
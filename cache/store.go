@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"testcontainers-learning/redis"
+)
+
+// ErrMiss는 Store에 키가 없을 때 반환됩니다
+var ErrMiss = errors.New("cache miss")
+
+// Store는 캐시 항목을 보관하는 저장소입니다
+type Store interface {
+	// Get은 키의 값을 조회합니다 (없으면 ErrMiss)
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set은 키에 값을 ttl 동안 저장합니다
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete는 키를 삭제합니다
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// VersionedStore는 항목의 버전을 비교해 저장할 수 있는 Store입니다
+//
+// Cache.SetVersion과 로드 결과 저장에 사용됩니다
+type VersionedStore interface {
+	Store
+	// SetVersioned는 현재 항목이 value보다 새로운 버전이 아닐 때만 저장하고 저장 여부를 반환합니다
+	//
+	// 버전은 Cache가 항목 앞에 붙인 헤더로 판단하며, 버전이 있는 항목은 같거나 낮은 버전의
+	// 값이나 버전이 없는 값(로드 결과, 네거티브 캐시)으로 덮어쓰지 않습니다
+	SetVersioned(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+}
+
+// setVersionedScript는 현재 항목의 버전이 새 값보다 낮을 때만 저장합니다
+//
+// 항목 형식은 splitVersioned와 같습니다 (0x02, 10진수 버전, ':', 값)
+var setVersionedScript = redis.NewScript(`
+local function version(entry)
+  if entry and string.byte(entry, 1) == 2 then
+    return tonumber(string.match(entry, '^.(%-?%d+):'))
+  end
+  return nil
+end
+
+local current = version(redis.call('GET', KEYS[1]))
+if current then
+  local new = version(ARGV[1])
+  if not new or new <= current then
+    return 0
+  end
+end
+
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+  redis.call('SET', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// redisStore는 Redis를 사용하는 Store입니다
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore는 Redis 클라이언트를 사용하는 Store를 생성합니다
+//
+// 반환된 Store는 Lua 스크립트로 버전을 비교하는 VersionedStore도 구현합니다
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, key)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, ErrMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl)
+}
+
+func (s *redisStore) Delete(ctx context.Context, keys ...string) error {
+	return s.client.Delete(ctx, keys...)
+}
+
+func (s *redisStore) SetVersioned(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	reply, err := s.client.RunScript(ctx, setVersionedScript, []string{key}, value, ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	stored, _ := reply.(int64)
+	return stored == 1, nil
+}
@@ -0,0 +1,393 @@
+// Package counterflush는 Redis 카운터를 주기적으로 PostgreSQL 집계 테이블에 반영합니다
+//
+// "pageviews:article-1"처럼 패턴과 일치하는 카운터 값을 Lua 스크립트로 원자적으로 읽고 지운 뒤,
+// 배치 ID(멱등성 키)와 함께 하나의 트랜잭션으로 upsert합니다. 반영이 끝나기 전에 실패하면
+// 배치는 Redis에 남아 같은 ID로 다시 시도되고(at-least-once), 이미 커밋된 배치는
+// 배치 테이블의 기본 키로 걸러지므로 같은 증가량이 두 번 더해지지 않습니다
+package counterflush
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"testcontainers-learning/postgres"
+	"testcontainers-learning/redis"
+)
+
+// 기본 설정값
+const (
+	DefaultPattern  = "pageviews:*"
+	DefaultInterval = 10 * time.Second
+)
+
+// scanCount는 SCAN 한 번에 가져올 키 수의 힌트입니다
+const scanCount = 100
+
+var (
+	// DefaultTable은 카운터를 누적할 기본 테이블입니다 (page_id, views 컬럼)
+	DefaultTable = postgres.MustParseIdentifier("page_views")
+	// DefaultBatchTable은 반영한 배치 ID를 기록하는 기본 테이블입니다
+	DefaultBatchTable = postgres.MustParseIdentifier("counter_flushes")
+)
+
+// config는 Flusher 설정입니다
+type config struct {
+	pattern     string
+	table       postgres.Identifier
+	keyColumn   string
+	countColumn string
+	batchTable  postgres.Identifier
+	interval    time.Duration
+	stateKey    string
+	onError     func(error)
+}
+
+// Option은 Flusher 동작을 설정합니다
+type Option func(*config)
+
+// WithPattern은 반영할 카운터 키의 SCAN 패턴을 설정합니다 (기본값 DefaultPattern)
+//
+// 테이블에는 패턴의 와일드카드 앞부분을 뗀 나머지가 키로 저장됩니다
+func WithPattern(pattern string) Option {
+	return func(c *config) {
+		c.pattern = pattern
+	}
+}
+
+// WithTable은 카운터를 누적할 테이블과 키, 카운트 컬럼을 설정합니다
+//
+// keyColumn에는 PRIMARY KEY나 UNIQUE 제약이 있어야 합니다
+func WithTable(table postgres.Identifier, keyColumn, countColumn string) Option {
+	return func(c *config) {
+		c.table = table
+		c.keyColumn = keyColumn
+		c.countColumn = countColumn
+	}
+}
+
+// WithBatchTable은 반영한 배치 ID를 기록할 테이블을 설정합니다
+//
+// 테이블에는 batch_id PRIMARY KEY 컬럼이 있어야 합니다
+func WithBatchTable(table postgres.Identifier) Option {
+	return func(c *config) {
+		c.batchTable = table
+	}
+}
+
+// WithInterval은 백그라운드 반영 주기를 설정합니다 (0 이하이면 Flush를 직접 호출할 때만 반영)
+func WithInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// WithStateKey는 진행 중인 배치와 락을 저장할 Redis 키 접두사를 설정합니다
+//
+// 기본값은 "counterflush:" 뒤에 패턴의 고정 부분을 붙인 값이며, 같은 카운터를 반영하는
+// 모든 인스턴스가 같은 값을 사용해야 합니다
+func WithStateKey(key string) Option {
+	return func(c *config) {
+		c.stateKey = key
+	}
+}
+
+// WithErrorHandler는 백그라운드 반영 중 발생한 에러를 받을 함수를 설정합니다
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Result는 Flush 한 번의 결과입니다
+type Result struct {
+	// Batches는 반영한 배치 수입니다 (이전에 실패한 배치 포함)
+	Batches int
+	// Duplicates는 이미 반영된 것으로 확인되어 건너뛴 배치 수입니다
+	Duplicates int
+	// Keys는 반영한 카운터 키 수입니다
+	Keys int
+	// Total은 반영한 증가량의 합계입니다
+	Total int64
+}
+
+// Flusher는 Redis 카운터를 PostgreSQL 테이블에 주기적으로 반영합니다
+type Flusher struct {
+	redis  *redis.Client
+	db     *postgres.Client
+	cfg    config
+	prefix string
+
+	inflightKey string
+	batchKey    string
+	lockKey     string
+	upsertSQL   string
+	batchSQL    string
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// New는 새로운 Flusher를 생성하고 백그라운드 반영을 시작합니다
+//
+// 종료할 때는 Close를 호출해 남은 카운터를 반영해야 합니다
+func New(rdb *redis.Client, db *postgres.Client, opts ...Option) (*Flusher, error) {
+	cfg := config{
+		pattern:     DefaultPattern,
+		table:       DefaultTable,
+		keyColumn:   "page_id",
+		countColumn: "views",
+		batchTable:  DefaultBatchTable,
+		interval:    DefaultInterval,
+		onError:     func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.table.IsZero() || cfg.batchTable.IsZero() {
+		return nil, errors.New("counterflush: table must not be empty")
+	}
+	keyColumn, err := parseColumn(cfg.keyColumn)
+	if err != nil {
+		return nil, err
+	}
+	countColumn, err := parseColumn(cfg.countColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := cfg.pattern
+	if i := strings.IndexAny(prefix, `*?[\`); i >= 0 {
+		prefix = prefix[:i]
+	}
+	if cfg.stateKey == "" {
+		cfg.stateKey = "counterflush:" + strings.TrimSuffix(prefix, ":")
+	}
+
+	f := &Flusher{
+		redis:       rdb,
+		db:          db,
+		cfg:         cfg,
+		prefix:      prefix,
+		inflightKey: cfg.stateKey + ":inflight",
+		batchKey:    cfg.stateKey + ":batch",
+		lockKey:     cfg.stateKey + ":lock",
+		upsertSQL: fmt.Sprintf(
+			`INSERT INTO %[1]s AS dst (%[2]s, %[3]s) SELECT * FROM unnest($1::text[], $2::bigint[])
+			ON CONFLICT (%[2]s) DO UPDATE SET %[3]s = dst.%[3]s + EXCLUDED.%[3]s`,
+			cfg.table, keyColumn, countColumn),
+		batchSQL: fmt.Sprintf(`INSERT INTO %s (batch_id) VALUES ($1) ON CONFLICT (batch_id) DO NOTHING`, cfg.batchTable),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	if cfg.interval > 0 {
+		go f.run()
+	} else {
+		close(f.stopped)
+	}
+	return f, nil
+}
+
+// parseColumn은 컬럼 이름을 검증하고 인용된 형태로 반환합니다
+func parseColumn(name string) (string, error) {
+	id, err := postgres.ParseIdentifier(name)
+	if err != nil {
+		return "", err
+	}
+	if id.Schema() != "" {
+		return "", fmt.Errorf("counterflush: column %q must not be qualified", name)
+	}
+	return id.String(), nil
+}
+
+// Flush는 현재까지 쌓인 카운터를 반영합니다
+//
+// 다른 인스턴스가 반영 중이면 끝날 때까지 기다립니다. 이전에 실패한 배치가 남아 있으면
+// 먼저 같은 배치 ID로 다시 반영합니다. 정수가 아닌 카운터는 건너뛰고 에러로 보고합니다
+func (f *Flusher) Flush(ctx context.Context) (Result, error) {
+	lock, err := f.redis.Lock(ctx, f.lockKey)
+	if err != nil {
+		return Result{}, err
+	}
+	defer lock.Unlock(context.WithoutCancel(ctx))
+
+	return f.flush(ctx)
+}
+
+// Close는 백그라운드 반영을 멈추고 남은 카운터를 마지막으로 반영합니다
+func (f *Flusher) Close(ctx context.Context) error {
+	first := false
+	f.closeOnce.Do(func() {
+		first = true
+		close(f.done)
+	})
+	if !first {
+		return nil
+	}
+
+	select {
+	case <-f.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	_, err := f.Flush(ctx)
+	return err
+}
+
+// run은 주기적으로 카운터를 반영합니다
+func (f *Flusher) run() {
+	defer close(f.stopped)
+
+	ticker := time.NewTicker(f.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+		}
+
+		// 다른 인스턴스가 반영 중이면 이번 주기는 건너뜀
+		lock, err := f.redis.TryLock(context.Background(), f.lockKey)
+		if errors.Is(err, redis.ErrLockNotAcquired) {
+			continue
+		}
+		if err != nil {
+			f.cfg.onError(err)
+			continue
+		}
+		if _, err := f.flush(context.Background()); err != nil {
+			f.cfg.onError(err)
+		}
+		lock.Unlock(context.Background())
+	}
+}
+
+// flush는 락을 잡은 상태에서 남은 배치와 새 배치를 반영합니다
+func (f *Flusher) flush(ctx context.Context) (Result, error) {
+	var res Result
+
+	// 이전에 실패한 배치 재시도
+	if err := f.applyPending(ctx, &res); err != nil {
+		return res, err
+	}
+
+	batchID, err := newBatchID()
+	if err != nil {
+		return res, err
+	}
+	if err := f.redis.Set(ctx, f.batchKey, batchID, 0); err != nil {
+		return res, err
+	}
+
+	var errs []error
+	for key, err := range f.redis.Scan(ctx, f.cfg.pattern, scanCount) {
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if key == f.inflightKey || key == f.batchKey || key == f.lockKey {
+			continue
+		}
+		field := strings.TrimPrefix(key, f.prefix)
+		if _, err := f.redis.RunScript(ctx, swapScript, []string{key, f.inflightKey}, field); err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("counterflush: swap %s: %w", key, err))
+		}
+	}
+
+	if err := f.applyPending(ctx, &res); err != nil {
+		errs = append(errs, err)
+	}
+	return res, errors.Join(errs...)
+}
+
+// applyPending은 Redis에 남아 있는 배치를 트랜잭션으로 반영하고 지웁니다
+func (f *Flusher) applyPending(ctx context.Context, res *Result) error {
+	batchID, err := f.redis.Get(ctx, f.batchKey)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	values, err := f.redis.HGetAll(ctx, f.inflightKey)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	counts := make([]int64, 0, len(values))
+	var total int64
+	for key, value := range values {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("counterflush: invalid count %q for %s: %w", value, key, err)
+		}
+		if n == 0 {
+			continue
+		}
+		keys = append(keys, key)
+		counts = append(counts, n)
+		total += n
+	}
+
+	if len(keys) > 0 {
+		duplicate := false
+		err := f.db.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+			result, err := tx.ExecContext(ctx, f.batchSQL, batchID)
+			if err != nil {
+				return err
+			}
+			if n, err := result.RowsAffected(); err != nil {
+				return err
+			} else if n == 0 {
+				// 이미 커밋된 배치 (커밋 후 Redis 정리 전에 실패한 경우)
+				duplicate = true
+				return nil
+			}
+			_, err = tx.ExecContext(ctx, f.upsertSQL, pq.Array(keys), pq.Array(counts))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("counterflush: apply batch %s: %w", batchID, err)
+		}
+
+		if duplicate {
+			res.Duplicates++
+		} else {
+			res.Batches++
+			res.Keys += len(keys)
+			res.Total += total
+		}
+	}
+
+	// 락을 잃은 사이 다른 인스턴스가 새 배치를 시작했다면 지우지 않음
+	_, err = f.redis.RunScript(ctx, clearScript, []string{f.batchKey, f.inflightKey}, batchID)
+	return err
+}
+
+// newBatchID는 배치의 멱등성 키로 사용할 임의의 ID를 만듭니다
+func newBatchID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,288 @@
+package counterflush
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	pgModule "github.com/testcontainers/testcontainers-go/modules/postgres"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"testcontainers-learning/postgres"
+	"testcontainers-learning/redis"
+)
+
+var (
+	testPostgres *postgres.Client
+	testRedis    *redis.Client
+)
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// PostgreSQL과 Redis 컨테이너 시작 (모든 테스트에서 공유)
+	postgresContainer, err := pgModule.Run(ctx,
+		"postgres:18-alpine",
+		pgModule.WithDatabase("testdb"),
+		pgModule.WithUsername("testuser"),
+		pgModule.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	if err != nil {
+		_ = testcontainers.TerminateContainer(postgresContainer)
+		panic(err)
+	}
+
+	terminate := func() {
+		_ = testcontainers.TerminateContainer(redisContainer)
+		_ = testcontainers.TerminateContainer(postgresContainer)
+	}
+
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		terminate()
+		panic(err)
+	}
+	testPostgres, err = postgres.NewClient(connStr)
+	if err != nil {
+		terminate()
+		panic(err)
+	}
+
+	migrator, err := postgres.NewMigrator(testPostgres, postgres.SchemaMigrations())
+	if err == nil {
+		_, err = migrator.Up(ctx)
+	}
+	if err != nil {
+		testPostgres.Close()
+		terminate()
+		panic(err)
+	}
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		testPostgres.Close()
+		terminate()
+		panic(err)
+	}
+	testRedis = redis.NewClient(endpoint)
+
+	// 테스트 실행
+	code := m.Run()
+
+	// 정리
+	testRedis.Close()
+	testPostgres.Close()
+	terminate()
+
+	os.Exit(code)
+}
+
+// newFlusher는 테스트마다 다른 키 패턴을 쓰는 Flusher를 만듭니다
+//
+// 페이지 ID는 "<테스트 이름>-<id>" 형식으로 저장됩니다
+func newFlusher(t *testing.T, opts ...Option) *Flusher {
+	opts = append([]Option{WithPattern("pv:" + t.Name() + "-*"), WithInterval(0)}, opts...)
+	f, err := New(testRedis, testPostgres, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close(context.Background()) })
+	return f
+}
+
+// hit은 페이지 조회수를 n만큼 증가시킵니다
+func hit(t *testing.T, page string, n int) {
+	for i := 0; i < n; i++ {
+		_, err := testRedis.Increment(context.Background(), "pv:"+t.Name()+"-"+page)
+		require.NoError(t, err)
+	}
+}
+
+// views는 page_views 테이블에 반영된 조회수를 반환합니다 (행이 없으면 0)
+func views(t *testing.T, page string) int64 {
+	var n int64
+	err := testPostgres.ExecuteInTransactionWithOptions(context.Background(), nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		return tx.GetContext(ctx, &n, `SELECT COALESCE(SUM(views), 0) FROM page_views WHERE page_id = $1`, t.Name()+"-"+page)
+	})
+	require.NoError(t, err)
+	return n
+}
+
+func TestFlush(t *testing.T) {
+	ctx := context.Background()
+	f := newFlusher(t)
+
+	hit(t, "a", 3)
+	hit(t, "b", 2)
+
+	res, err := f.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Batches: 1, Keys: 2, Total: 5}, res)
+	assert.Equal(t, int64(3), views(t, "a"))
+	assert.Equal(t, int64(2), views(t, "b"))
+
+	// 반영된 카운터는 Redis에서 사라짐
+	n, err := testRedis.Exists(ctx, "pv:"+t.Name()+"-a", f.inflightKey, f.batchKey)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// 새로 쌓인 증가량만 누적
+	hit(t, "a", 4)
+	res, err = f.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Batches: 1, Keys: 1, Total: 4}, res)
+	assert.Equal(t, int64(7), views(t, "a"))
+	assert.Equal(t, int64(2), views(t, "b"))
+
+	// 쌓인 카운터가 없으면 아무것도 하지 않음
+	res, err = f.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{}, res)
+}
+
+func TestFlushRetriesFailedBatch(t *testing.T) {
+	ctx := context.Background()
+
+	// 존재하지 않는 테이블로는 반영 실패
+	broken := newFlusher(t, WithTable(postgres.MustParseIdentifier("missing_page_views"), "page_id", "views"))
+	hit(t, "a", 2)
+	_, err := broken.Flush(ctx)
+	require.Error(t, err)
+
+	// 실패한 배치는 Redis에 남아 있음
+	pending, err := testRedis.HGetAll(ctx, broken.inflightKey)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "2"}, pending)
+
+	// 그 사이 쌓인 증가량은 다음 배치로 반영
+	hit(t, "a", 1)
+	f := newFlusher(t)
+	res, err := f.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Batches: 2, Keys: 2, Total: 3}, res)
+	assert.Equal(t, int64(3), views(t, "a"))
+}
+
+func TestFlushSkipsAppliedBatch(t *testing.T) {
+	ctx := context.Background()
+	f := newFlusher(t)
+
+	// 커밋 후 Redis 정리 전에 죽은 상황 재현: 배치는 Redis에 남아 있지만 이미 반영됨
+	batchID := "already-applied-" + t.Name()
+	require.NoError(t, testRedis.Set(ctx, f.batchKey, batchID, 0))
+	require.NoError(t, testRedis.HSet(ctx, f.inflightKey, "a", 5))
+	err := testPostgres.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO counter_flushes (batch_id) VALUES ($1)`, batchID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO page_views (page_id, views) VALUES ($1, 5)`, t.Name()+"-a")
+		return err
+	})
+	require.NoError(t, err)
+
+	res, err := f.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Duplicates: 1}, res)
+	assert.Equal(t, int64(5), views(t, "a"))
+
+	n, err := testRedis.Exists(ctx, f.inflightKey, f.batchKey)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestFlushInvalidCounter(t *testing.T) {
+	ctx := context.Background()
+	f := newFlusher(t)
+
+	badKey := "pv:" + t.Name() + "-bad"
+	require.NoError(t, testRedis.Set(ctx, badKey, "not-a-number", 0))
+	hit(t, "a", 2)
+
+	// 정수가 아닌 카운터는 남겨 두고 나머지는 반영
+	res, err := f.Flush(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), badKey)
+	assert.Equal(t, Result{Batches: 1, Keys: 1, Total: 2}, res)
+	assert.Equal(t, int64(2), views(t, "a"))
+
+	value, err := testRedis.Get(ctx, badKey)
+	require.NoError(t, err)
+	assert.Equal(t, "not-a-number", value)
+}
+
+func TestFlushBackgroundAndClose(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var errs []error
+	f := newFlusher(t, WithInterval(50*time.Millisecond), WithErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+
+	hit(t, "a", 3)
+	assert.Eventually(t, func() bool { return views(t, "a") == 3 }, 5*time.Second, 20*time.Millisecond)
+
+	// Close는 남은 카운터를 반영한 뒤 종료
+	hit(t, "a", 2)
+	require.NoError(t, f.Close(ctx))
+	assert.Equal(t, int64(5), views(t, "a"))
+
+	// Close 이후에는 백그라운드 반영이 없음
+	hit(t, "a", 1)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int64(5), views(t, "a"))
+	require.NoError(t, f.Close(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Empty(t, errs)
+}
+
+func TestFlushConcurrentInstances(t *testing.T) {
+	ctx := context.Background()
+
+	// 같은 카운터를 반영하는 인스턴스 여러 개
+	flushers := make([]*Flusher, 3)
+	for i := range flushers {
+		flushers[i] = newFlusher(t, WithInterval(10*time.Millisecond))
+	}
+
+	const pages, hits = 5, 40
+	var wg sync.WaitGroup
+	for p := 0; p < pages; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hit(t, fmt.Sprint(p), hits)
+		}()
+	}
+	wg.Wait()
+
+	for _, f := range flushers {
+		require.NoError(t, f.Close(ctx))
+	}
+
+	// 증가량이 빠지거나 두 번 더해지지 않음
+	for p := 0; p < pages; p++ {
+		assert.Equal(t, int64(hits), views(t, fmt.Sprint(p)), "page %d", p)
+	}
+}
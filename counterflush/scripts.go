@@ -0,0 +1,32 @@
+package counterflush
+
+import "testcontainers-learning/redis"
+
+// swapScript는 카운터 값을 읽고 지운 뒤 진행 중인 배치 해시에 더합니다
+//
+// 정수가 아닌 값은 건드리지 않고 에러를 반환합니다
+//
+// KEYS[1]: 카운터, KEYS[2]: 진행 중인 배치 해시, ARGV: 해시 필드
+var swapScript = redis.NewScript(`
+local v = redis.call('GET', KEYS[1])
+if not v then
+	return 0
+end
+local n = tonumber(v)
+if not n or n ~= math.floor(n) then
+	return redis.error_reply('counter ' .. KEYS[1] .. ' is not an integer')
+end
+redis.call('DEL', KEYS[1])
+redis.call('HINCRBY', KEYS[2], ARGV[1], n)
+return n
+`)
+
+// clearScript는 배치 ID가 일치할 때만 진행 중인 배치를 지웁니다
+//
+// KEYS[1]: 배치 ID, KEYS[2]: 진행 중인 배치 해시, ARGV: 배치 ID
+var clearScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1], KEYS[2])
+end
+return 0
+`)
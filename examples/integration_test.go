@@ -2,13 +2,16 @@ package examples
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
@@ -17,11 +20,16 @@ import (
 	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"testcontainers-learning/cache"
+	"testcontainers-learning/counterflush"
 	dynamoClient "testcontainers-learning/dynamodb"
 	pgClient "testcontainers-learning/postgres"
 	redisClient "testcontainers-learning/redis"
 )
 
+// usersTable은 예제에서 사용하는 사용자 테이블 식별자입니다
+var usersTable = pgClient.MustParseIdentifier("users")
+
 // TestMultiContainerIntegration은 여러 컨테이너를 동시에 사용하는 통합 테스트입니다
 func TestMultiContainerIntegration(t *testing.T) {
 	ctx := context.Background()
@@ -100,11 +108,11 @@ func TestMultiContainerIntegration(t *testing.T) {
 	// 5. 통합 테스트 시나리오: 사용자 등록 및 세션 관리
 
 	// PostgreSQL에 사용자 테이블 생성
-	err = postgres.CreateTable(ctx, "users")
+	err = postgres.CreateTable(ctx, usersTable)
 	require.NoError(t, err)
 
 	// PostgreSQL에 사용자 추가
-	userID, err := postgres.InsertUser(ctx, "users", "John Doe", "john@example.com")
+	userID, err := postgres.InsertUser(ctx, usersTable, "John Doe", "john@example.com")
 	require.NoError(t, err)
 	assert.Greater(t, userID, int64(0))
 
@@ -135,7 +143,7 @@ func TestMultiContainerIntegration(t *testing.T) {
 	assert.Equal(t, "active", session)
 
 	// PostgreSQL에서 사용자 조회
-	user, err := postgres.GetUser(ctx, "users", userID)
+	user, err := postgres.GetUser(ctx, usersTable, userID)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, "John Doe", user.Name)
@@ -188,37 +196,61 @@ func TestCacheAsidePattern(t *testing.T) {
 	defer postgres.Close()
 
 	// 테이블 생성 및 데이터 추가
-	err = postgres.CreateTable(ctx, "users")
+	err = postgres.CreateTable(ctx, usersTable)
 	require.NoError(t, err)
 
-	userID, err := postgres.InsertUser(ctx, "users", "Jane Smith", "jane@example.com")
+	userID, err := postgres.InsertUser(ctx, usersTable, "Jane Smith", "jane@example.com")
 	require.NoError(t, err)
 
-	// 캐시 어사이드 패턴 구현
-	cacheKey := fmt.Sprintf("user:%d", userID)
+	// 캐시 어사이드 패턴: 캐시 미스 시 PostgreSQL에서 읽어 Redis에 저장
+	var loads atomic.Int32
+	users := cache.New(cache.NewRedisStore(redis),
+		func(ctx context.Context, id int64) (*pgClient.User, error) {
+			loads.Add(1)
+			return postgres.GetUser(ctx, usersTable, id)
+		},
+		cache.WithPrefix("user"),
+		cache.WithTTL(5*time.Minute),
+		cache.WithJitter(0.1),
+		cache.WithNegativeTTL(30*time.Second),
+		cache.WithNotFound(func(err error) bool {
+			return errors.Is(err, pgClient.ErrNotFound)
+		}),
+	)
 
-	// 1. 캐시에서 먼저 조회
-	cachedData, err := redis.Get(ctx, cacheKey)
-	if err != nil {
-		// 2. 캐시 미스 - DB에서 조회
-		user, err := postgres.GetUser(ctx, "users", userID)
-		require.NoError(t, err)
-		require.NotNil(t, user)
+	// 1. 첫 조회 - 캐시 미스, DB에서 조회 후 캐시에 저장
+	user, err := users.Get(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Smith", user.Name)
+	assert.Equal(t, "jane@example.com", user.Email)
+	assert.Equal(t, int32(1), loads.Load())
 
-		// 3. 캐시에 저장
-		cacheValue := fmt.Sprintf("%s:%s", user.Name, user.Email)
-		err = redis.Set(ctx, cacheKey, cacheValue, 5*time.Minute)
-		require.NoError(t, err)
+	// 2. 다시 조회 - 이번에는 캐시 히트
+	user, err = users.Get(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Smith", user.Name)
+	assert.Equal(t, int32(1), loads.Load())
 
-		t.Log("캐시 미스 - DB에서 조회 후 캐시에 저장")
-	} else {
-		t.Logf("캐시 히트 - 캐시된 데이터: %s", cachedData)
-	}
+	// 3. DB 갱신 후 캐시 무효화 - 다음 조회는 새 값을 읽음
+	err = postgres.UpdateUser(ctx, usersTable, userID, "Jane Doe", "jane@example.com")
+	require.NoError(t, err)
+	require.NoError(t, users.Invalidate(ctx, userID))
 
-	// 4. 다시 조회 - 이번에는 캐시 히트
-	cachedData, err = redis.Get(ctx, cacheKey)
-	assert.NoError(t, err)
-	assert.Equal(t, "Jane Smith:jane@example.com", cachedData)
+	// 무효화된 키는 장애가 아닌 ErrNotFound로 구분됨
+	_, err = redis.Get(ctx, fmt.Sprintf("user:%d", userID))
+	assert.ErrorIs(t, err, redisClient.ErrNotFound)
+
+	user, err = users.Get(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", user.Name)
+	assert.Equal(t, int32(2), loads.Load())
+
+	// 4. 없는 사용자 - 네거티브 캐시로 DB 조회는 한 번만 발생
+	for i := 0; i < 3; i++ {
+		_, err = users.Get(ctx, 9999)
+		assert.ErrorIs(t, err, cache.ErrNotFound)
+	}
+	assert.Equal(t, int32(3), loads.Load())
 
 	t.Log("캐시 어사이드 패턴 테스트 성공")
 }
@@ -276,10 +308,31 @@ func TestDistributedCounter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "10", count)
 
-	// 주기적으로 DB에 동기화하는 시나리오
-	// (실제로는 백그라운드 작업으로 처리)
-	// 여기서는 테스트를 위해 수동으로 수행
+	// 주기적으로 DB에 동기화 (여기서는 Flush로 즉시 반영)
+	migrator, err := pgClient.NewMigrator(postgres, pgClient.SchemaMigrations())
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	flusher, err := counterflush.New(redis, postgres, counterflush.WithInterval(time.Minute))
+	require.NoError(t, err)
+
+	res, err := flusher.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), res.Total)
+
+	// 반영 후 쌓인 조회수는 종료 시 반영
+	_, err = redis.Increment(ctx, counterKey)
+	require.NoError(t, err)
+	require.NoError(t, flusher.Close(ctx))
+
+	var views int64
+	err = postgres.ExecuteInTransaction(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		return tx.GetContext(ctx, &views, "SELECT views FROM page_views WHERE page_id = $1", pageID)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), views)
 
-	t.Logf("페이지 %s의 조회수: %s", pageID, count)
+	t.Logf("페이지 %s의 조회수: %d", pageID, views)
 	t.Log("분산 카운터 패턴 테스트 성공")
 }
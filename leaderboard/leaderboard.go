@@ -0,0 +1,227 @@
+// Package leaderboard는 Redis 정렬된 집합(ZSET)으로 구현한 순위표를 제공합니다
+//
+// 점수가 높을수록 순위가 높으며, 점수가 같으면 멤버 이름의 역순으로 정렬됩니다.
+// 순위 조회, 페이지 단위 상위 N명, 내 주변 순위 조회와 함께 오래된 점수의 영향을
+// 줄이기 위해 모든 점수에 일정 비율을 곱하는 감쇠(decay)를 지원합니다
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"testcontainers-learning/redis"
+)
+
+// DefaultPrefix는 Redis 키 접두사의 기본값입니다
+const DefaultPrefix = "leaderboard"
+
+// ErrMemberNotFound는 순위표에 없는 멤버를 조회했을 때 반환됩니다
+var ErrMemberNotFound = errors.New("member not found")
+
+// Entry는 순위표의 한 항목입니다
+type Entry struct {
+	Member string
+	Score  float64
+	// Rank는 1부터 시작하는 순위입니다
+	Rank int64
+}
+
+// config는 Leaderboard 설정입니다
+type config struct {
+	prefix     string
+	decayFloor *float64
+	onError    func(error)
+}
+
+// Option은 Leaderboard 동작을 설정합니다
+type Option func(*config)
+
+// WithPrefix는 Redis 키 접두사를 설정합니다 (기본값 DefaultPrefix)
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// WithDecayFloor는 감쇠 후 점수가 floor보다 낮아진 멤버를 순위표에서 삭제하도록 설정합니다
+func WithDecayFloor(floor float64) Option {
+	return func(c *config) {
+		c.decayFloor = &floor
+	}
+}
+
+// WithErrorHandler는 RunDecay에서 호출자에게 반환할 수 없는 에러를 받을 함수를 설정합니다
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Leaderboard는 Redis ZSET 기반 순위표입니다
+type Leaderboard struct {
+	client *redis.Client
+	name   string
+	cfg    config
+
+	scoresKey string
+	decayKey  string
+}
+
+// New는 name 순위표를 생성합니다
+//
+// 키에는 "{name}" 해시 태그가 붙으므로 Cluster에서도 감쇠 스크립트가 같은 슬롯에서 실행됩니다
+func New(client *redis.Client, name string, opts ...Option) *Leaderboard {
+	cfg := config{
+		prefix:  DefaultPrefix,
+		onError: func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	base := cfg.prefix + ":{" + name + "}"
+	return &Leaderboard{
+		client:    client,
+		name:      name,
+		cfg:       cfg,
+		scoresKey: base + ":scores",
+		decayKey:  base + ":decay",
+	}
+}
+
+// Name은 순위표 이름을 반환합니다
+func (l *Leaderboard) Name() string {
+	return l.name
+}
+
+// Set은 멤버의 점수를 score로 설정합니다
+func (l *Leaderboard) Set(ctx context.Context, member string, score float64) error {
+	_, err := l.client.ZAdd(ctx, l.scoresKey, redis.Z{Member: member, Score: score})
+	return err
+}
+
+// Add는 멤버의 점수에 delta를 더하고 새 점수를 반환합니다 (없는 멤버는 0에서 시작)
+func (l *Leaderboard) Add(ctx context.Context, member string, delta float64) (float64, error) {
+	return l.client.ZIncrBy(ctx, l.scoresKey, member, delta)
+}
+
+// Remove는 멤버를 순위표에서 삭제합니다
+func (l *Leaderboard) Remove(ctx context.Context, members ...string) error {
+	_, err := l.client.ZRem(ctx, l.scoresKey, members...)
+	return err
+}
+
+// Count는 순위표의 멤버 수를 반환합니다
+func (l *Leaderboard) Count(ctx context.Context) (int64, error) {
+	return l.client.ZCard(ctx, l.scoresKey)
+}
+
+// Rank는 멤버의 순위와 점수를 조회합니다 (없으면 ErrMemberNotFound)
+func (l *Leaderboard) Rank(ctx context.Context, member string) (Entry, error) {
+	entries, err := l.Around(ctx, member, 0)
+	if err != nil {
+		return Entry{}, err
+	}
+	return entries[0], nil
+}
+
+// Top은 offset번째(0부터)부터 최대 limit명을 순위 순서로 조회합니다
+//
+// 페이지 단위로 조회할 때는 offset에 (page-1)*limit를 전달합니다
+func (l *Leaderboard) Top(ctx context.Context, offset, limit int64) ([]Entry, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	zs, err := l.client.ZRevRange(ctx, l.scoresKey, offset, offset+limit-1)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, len(zs))
+	for i, z := range zs {
+		entries[i] = Entry{Member: z.Member, Score: z.Score, Rank: offset + int64(i) + 1}
+	}
+	return entries, nil
+}
+
+// Around는 멤버와 그 위아래 radius명을 순위 순서로 조회합니다 (없으면 ErrMemberNotFound)
+//
+// 순위 조회와 범위 조회를 스크립트 하나로 실행하므로 그 사이에 점수가 바뀌어도
+// 멤버가 항상 결과에 포함됩니다
+func (l *Leaderboard) Around(ctx context.Context, member string, radius int64) ([]Entry, error) {
+	reply, err := l.client.RunScript(ctx, aroundScript, []string{l.scoresKey}, member, max(radius, 0))
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := reply.([]interface{})
+	if !ok || len(result) != 2 {
+		return nil, fmt.Errorf("leaderboard: unexpected reply %v", reply)
+	}
+	start, _ := result[0].(int64)
+	flat, _ := result[1].([]interface{})
+
+	entries := make([]Entry, 0, len(flat)/2)
+	for i := 0; i+1 < len(flat); i += 2 {
+		name, _ := flat[i].(string)
+		raw, _ := flat[i+1].(string)
+		score, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("leaderboard: score of %s: %w", name, err)
+		}
+		entries = append(entries, Entry{Member: name, Score: score, Rank: start + int64(len(entries)) + 1})
+	}
+	return entries, nil
+}
+
+// Decay는 모든 점수에 factor를 곱하고 WithDecayFloor보다 낮아진 멤버를 삭제합니다
+//
+// 삭제된 멤버 수를 반환합니다. 스크립트 하나로 모든 멤버를 갱신하므로 멤버 수에
+// 비례하는 시간 동안 Redis를 점유합니다
+func (l *Leaderboard) Decay(ctx context.Context, factor float64) (int64, error) {
+	return l.decay(ctx, factor, 0)
+}
+
+// RunDecay는 ctx가 취소될 때까지 interval마다 모든 점수에 factor를 곱합니다
+//
+// 여러 인스턴스에서 실행해도 interval마다 한 번만 감쇠되도록 Redis에 주기를 기록합니다
+func (l *Leaderboard) RunDecay(ctx context.Context, interval time.Duration, factor float64) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		// 인스턴스 간 타이머 오차로 다음 주기를 건너뛰지 않도록 주기보다 조금 짧게 표시
+		if _, err := l.decay(ctx, factor, interval*9/10); err != nil && ctx.Err() == nil {
+			l.cfg.onError(fmt.Errorf("leaderboard: decay %s: %w", l.name, err))
+		}
+	}
+}
+
+// decay는 period 동안 한 번만 감쇠를 실행합니다 (period가 0이면 항상 실행)
+//
+// 이번 주기에 이미 실행되었으면 -1을 반환합니다
+func (l *Leaderboard) decay(ctx context.Context, factor float64, period time.Duration) (int64, error) {
+	floor := ""
+	if l.cfg.decayFloor != nil {
+		floor = strconv.FormatFloat(*l.cfg.decayFloor, 'g', -1, 64)
+	}
+
+	reply, err := l.client.RunScript(ctx, decayScript, []string{l.scoresKey, l.decayKey}, factor, floor, period.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+	removed, _ := reply.(int64)
+	return removed, nil
+}
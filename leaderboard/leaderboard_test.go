@@ -0,0 +1,221 @@
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"testcontainers-learning/redis"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Redis 컨테이너 시작 (모든 테스트에서 공유)
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	if err != nil {
+		panic(err)
+	}
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		_ = testcontainers.TerminateContainer(redisContainer)
+		panic(err)
+	}
+	testClient = redis.NewClient(endpoint)
+
+	// 테스트 실행
+	code := m.Run()
+
+	// 정리
+	testClient.Close()
+	if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+		panic(err)
+	}
+
+	os.Exit(code)
+}
+
+// newLeaderboard는 테스트마다 다른 접두사를 쓰는 Leaderboard를 만듭니다
+func newLeaderboard(t *testing.T, opts ...Option) *Leaderboard {
+	prefix := "test:" + t.Name()
+	ctx := context.Background()
+	for key, err := range testClient.Scan(ctx, prefix+":*", 100) {
+		require.NoError(t, err)
+		require.NoError(t, testClient.Delete(ctx, key))
+	}
+
+	opts = append([]Option{WithPrefix(prefix)}, opts...)
+	return New(testClient, "weekly", opts...)
+}
+
+// seed는 player-1부터 player-n까지 i*10점으로 등록합니다
+func seed(t *testing.T, lb *Leaderboard, n int) {
+	for i := 1; i <= n; i++ {
+		require.NoError(t, lb.Set(context.Background(), fmt.Sprintf("player-%d", i), float64(i*10)))
+	}
+}
+
+func TestLeaderboardRank(t *testing.T) {
+	ctx := context.Background()
+	lb := newLeaderboard(t)
+	seed(t, lb, 5)
+
+	entry, err := lb.Rank(ctx, "player-5")
+	require.NoError(t, err)
+	assert.Equal(t, Entry{Member: "player-5", Score: 50, Rank: 1}, entry)
+
+	// 점수를 더하면 순위가 바뀜
+	score, err := lb.Add(ctx, "player-1", 100)
+	require.NoError(t, err)
+	assert.Equal(t, float64(110), score)
+
+	entry, err = lb.Rank(ctx, "player-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), entry.Rank)
+
+	entry, err = lb.Rank(ctx, "player-5")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entry.Rank)
+
+	// 새 멤버는 0점에서 시작
+	score, err = lb.Add(ctx, "newcomer", 5)
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), score)
+
+	require.NoError(t, lb.Remove(ctx, "newcomer"))
+	_, err = lb.Rank(ctx, "newcomer")
+	assert.ErrorIs(t, err, ErrMemberNotFound)
+
+	count, err := lb.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+}
+
+func TestLeaderboardTop(t *testing.T) {
+	ctx := context.Background()
+	lb := newLeaderboard(t)
+	seed(t, lb, 25)
+
+	// 첫 페이지
+	page, err := lb.Top(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 10)
+	assert.Equal(t, Entry{Member: "player-25", Score: 250, Rank: 1}, page[0])
+	assert.Equal(t, Entry{Member: "player-16", Score: 160, Rank: 10}, page[9])
+
+	// 마지막 페이지는 남은 멤버만
+	page, err = lb.Top(ctx, 20, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 5)
+	assert.Equal(t, Entry{Member: "player-5", Score: 50, Rank: 21}, page[0])
+	assert.Equal(t, int64(25), page[4].Rank)
+
+	page, err = lb.Top(ctx, 30, 10)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func TestLeaderboardAround(t *testing.T) {
+	ctx := context.Background()
+	lb := newLeaderboard(t)
+	seed(t, lb, 10)
+
+	// 중간 순위는 위아래 radius명
+	entries, err := lb.Around(ctx, "player-5", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	assert.Equal(t, Entry{Member: "player-7", Score: 70, Rank: 4}, entries[0])
+	assert.Equal(t, Entry{Member: "player-5", Score: 50, Rank: 6}, entries[2])
+	assert.Equal(t, Entry{Member: "player-3", Score: 30, Rank: 8}, entries[4])
+
+	// 1등은 아래쪽만
+	entries, err = lb.Around(ctx, "player-10", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, int64(1), entries[0].Rank)
+	assert.Equal(t, "player-10", entries[0].Member)
+
+	// 꼴찌는 위쪽만
+	entries, err = lb.Around(ctx, "player-1", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, Entry{Member: "player-1", Score: 10, Rank: 10}, entries[2])
+
+	_, err = lb.Around(ctx, "nobody", 2)
+	assert.ErrorIs(t, err, ErrMemberNotFound)
+}
+
+func TestLeaderboardDecay(t *testing.T) {
+	ctx := context.Background()
+	lb := newLeaderboard(t, WithDecayFloor(5))
+	seed(t, lb, 4)
+
+	// 10, 20, 30, 40 -> 5, 10, 15, 20 (floor 5 이상은 유지)
+	removed, err := lb.Decay(ctx, 0.5)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	entries, err := lb.Top(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Member: "player-4", Score: 20, Rank: 1},
+		{Member: "player-3", Score: 15, Rank: 2},
+		{Member: "player-2", Score: 10, Rank: 3},
+		{Member: "player-1", Score: 5, Rank: 4},
+	}, entries)
+
+	// 5, 10, 15, 20 -> 2.5(삭제), 5, 7.5, 10
+	removed, err = lb.Decay(ctx, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	_, err = lb.Rank(ctx, "player-1")
+	assert.ErrorIs(t, err, ErrMemberNotFound)
+	entry, err := lb.Rank(ctx, "player-3")
+	require.NoError(t, err)
+	assert.Equal(t, 7.5, entry.Score)
+}
+
+func TestLeaderboardRunDecay(t *testing.T) {
+	lb := newLeaderboard(t)
+	seed(t, lb, 1)
+
+	var errs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 여러 인스턴스가 실행해도 주기마다 한 번만 감쇠
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		instance := New(testClient, "weekly", WithPrefix("test:"+t.Name()), WithErrorHandler(func(error) {
+			errs.Add(1)
+		}))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = instance.RunDecay(ctx, 200*time.Millisecond, 0.5)
+		}()
+	}
+
+	// 첫 주기 이후, 두 번째 주기 전
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	entry, err := lb.Rank(context.Background(), "player-1")
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), entry.Score)
+	assert.Zero(t, errs.Load())
+}
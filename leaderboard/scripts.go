@@ -0,0 +1,43 @@
+package leaderboard
+
+import "testcontainers-learning/redis"
+
+// aroundScript는 멤버의 순위와 앞뒤 radius명을 한 번에 조회합니다
+//
+// KEYS[1]: 점수 ZSET, ARGV: 멤버, radius
+// 반환값: {시작 순위(0부터), {멤버, 점수, ...}}, 멤버가 없으면 nil
+var aroundScript = redis.NewScript(`
+local rank = redis.call('ZREVRANK', KEYS[1], ARGV[1])
+if not rank then
+	return nil
+end
+local radius = tonumber(ARGV[2])
+local start = math.max(rank - radius, 0)
+return {start, redis.call('ZREVRANGE', KEYS[1], start, rank + radius, 'WITHSCORES')}
+`)
+
+// decayScript는 모든 점수에 factor를 곱하고 floor보다 낮아진 멤버를 삭제합니다
+//
+// KEYS[1]: 점수 ZSET, KEYS[2]: 주기 표시 키
+// ARGV: factor, floor(빈 문자열이면 삭제 안 함), 주기(ms, 0이면 항상 실행)
+// 반환값: 삭제된 멤버 수, 이번 주기에 이미 실행되었으면 -1
+var decayScript = redis.NewScript(`
+local period = tonumber(ARGV[3])
+if period > 0 and not redis.call('SET', KEYS[2], 1, 'NX', 'PX', period) then
+	return -1
+end
+local factor = tonumber(ARGV[1])
+local floor = tonumber(ARGV[2])
+local entries = redis.call('ZRANGE', KEYS[1], 0, -1, 'WITHSCORES')
+local removed = 0
+for i = 1, #entries, 2 do
+	local score = tonumber(entries[i + 1]) * factor
+	if floor and score < floor then
+		redis.call('ZREM', KEYS[1], entries[i])
+		removed = removed + 1
+	else
+		redis.call('ZADD', KEYS[1], score, entries[i])
+	end
+end
+return removed
+`)
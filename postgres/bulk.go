@@ -0,0 +1,376 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	// DefaultBulkBatchSize는 BulkOptions.BatchSize를 지정하지 않았을 때의 배치 크기입니다
+	DefaultBulkBatchSize = 1000
+
+	// maxBindParams는 PostgreSQL 한 문장에서 사용할 수 있는 최대 바인드 파라미터 수입니다
+	maxBindParams = 65535
+
+	// bulkStageTable은 생성된 컬럼 값을 미리 만들어 두는 임시 스테이징 테이블 이름입니다
+	bulkStageTable = "bulk_stage"
+	bulkOrdColumn  = "bulk_ord"
+)
+
+// BulkOptions는 대량 삽입 동작을 설정합니다
+type BulkOptions struct {
+	// BatchSize는 한 트랜잭션에서 처리할 행 수입니다 (기본값 DefaultBulkBatchSize)
+	BatchSize int
+	// ReturnGenerated가 true이면 DB가 생성한 auto 컬럼 값을 각 entity에 채웁니다
+	ReturnGenerated bool
+	// DisableCopy가 true이면 COPY 대신 다중 행 INSERT를 사용합니다
+	DisableCopy bool
+	// Progress는 각 배치가 커밋된 후 호출됩니다
+	Progress func(BulkProgress)
+}
+
+// BulkProgress는 배치 단위 진행 상황을 나타냅니다
+type BulkProgress struct {
+	Batch    int
+	Rows     int
+	Total    int64
+	UsedCopy bool
+}
+
+// BulkResult는 대량 삽입 결과를 나타냅니다
+type BulkResult struct {
+	Inserted int64
+	Batches  int
+	// UsedCopy는 모든 배치가 COPY로 처리되었는지 나타냅니다
+	UsedCopy bool
+}
+
+// BulkInsert는 entities를 배치 단위로 COPY FROM STDIN을 통해 삽입합니다
+//
+// 각 배치는 별도의 트랜잭션으로 커밋되므로 에러가 발생하면 이전 배치까지는 반영된 상태로 남습니다.
+// ctx에 트랜잭션이 있으면 각 배치는 SAVEPOINT로 실행되고 바깥 트랜잭션과 함께 커밋됩니다
+// COPY를 사용할 수 없는 경우 다중 행 INSERT ... VALUES로 자동 전환됩니다
+func (r *Repository[T]) BulkInsert(ctx context.Context, entities iter.Seq[*T], opts BulkOptions) (*BulkResult, error) {
+	if len(r.meta.insertCols) == 0 {
+		return nil, fmt.Errorf("table %s has no insertable columns", r.table)
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBulkBatchSize
+	}
+
+	result := &BulkResult{UsedCopy: !opts.DisableCopy}
+	useCopy := !opts.DisableCopy
+	batch := make([]reflect.Value, 0, batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		var err error
+		if useCopy {
+			err = r.copyBatch(ctx, batch, opts.ReturnGenerated)
+			if isCopyUnsupported(err) {
+				useCopy = false
+				result.UsedCopy = false
+				err = r.insertBatch(ctx, batch, opts.ReturnGenerated)
+			}
+		} else {
+			err = r.insertBatch(ctx, batch, opts.ReturnGenerated)
+		}
+		if err != nil {
+			return fmt.Errorf("bulk insert batch %d: %w", result.Batches+1, err)
+		}
+
+		result.Batches++
+		result.Inserted += int64(len(batch))
+		if opts.Progress != nil {
+			opts.Progress(BulkProgress{
+				Batch:    result.Batches,
+				Rows:     len(batch),
+				Total:    result.Inserted,
+				UsedCopy: useCopy,
+			})
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	index := 0
+	for entity := range entities {
+		if entity == nil {
+			return result, fmt.Errorf("bulk insert entity %d is nil", index)
+		}
+		index++
+		batch = append(batch, reflect.ValueOf(entity).Elem())
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// copyBatch는 한 배치를 COPY로 삽입합니다
+//
+// returnGenerated가 true이면 스테이징 테이블에 COPY한 뒤 대상 테이블로 옮깁니다 (moveStage 참고)
+func (r *Repository[T]) copyBatch(ctx context.Context, batch []reflect.Value, returnGenerated bool) error {
+	returning := returnGenerated && len(r.meta.returnCols) > 0
+
+	return r.client.ExecuteInTransaction(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		names := columnNames(r.meta.insertCols)
+
+		var copySQL string
+		if returning {
+			if err := r.createStage(ctx, tx); err != nil {
+				return err
+			}
+			copySQL = pq.CopyIn(bulkStageTable, append(names, bulkOrdColumn)...)
+		} else if r.table.Schema() != "" {
+			copySQL = pq.CopyInSchema(r.table.Schema(), r.table.Name(), names...)
+		} else {
+			copySQL = pq.CopyIn(r.table.Name(), names...)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, copySQL)
+		if err != nil {
+			return err
+		}
+
+		for i, entity := range batch {
+			args := columnValues(entity, r.meta.insertCols)
+			if returning {
+				args = append(args, int64(i))
+			}
+			if _, err := stmt.ExecContext(ctx, args...); err != nil {
+				stmt.Close()
+				return err
+			}
+		}
+		if _, err := stmt.ExecContext(ctx); err != nil {
+			stmt.Close()
+			return err
+		}
+		if err := stmt.Close(); err != nil {
+			return err
+		}
+
+		if !returning {
+			return nil
+		}
+		return r.moveStage(ctx, tx, batch)
+	})
+}
+
+// insertBatch는 한 배치를 다중 행 INSERT ... VALUES 문으로 삽입합니다
+//
+// returnGenerated가 true이면 스테이징 테이블에 삽입한 뒤 대상 테이블로 옮깁니다 (moveStage 참고)
+func (r *Repository[T]) insertBatch(ctx context.Context, batch []reflect.Value, returnGenerated bool) error {
+	returning := returnGenerated && len(r.meta.returnCols) > 0
+
+	target := r.table.String()
+	cols := quoteColumns(r.meta.insertCols)
+	if returning {
+		target = quoteIdent(bulkStageTable)
+		cols = append(cols, quoteIdent(bulkOrdColumn))
+	}
+	perRow := len(cols)
+	rowsPerStmt := maxBindParams / perRow
+
+	return r.client.ExecuteInTransaction(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if returning {
+			if err := r.createStage(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		for start := 0; start < len(batch); start += rowsPerStmt {
+			chunk := batch[start:min(start+rowsPerStmt, len(batch))]
+			tuples := make([]string, len(chunk))
+			args := make([]interface{}, 0, len(chunk)*perRow)
+			for i, entity := range chunk {
+				placeholders := make([]string, perRow)
+				for j := range placeholders {
+					placeholders[j] = fmt.Sprintf("$%d", i*perRow+j+1)
+				}
+				tuples[i] = "(" + strings.Join(placeholders, ", ") + ")"
+				args = append(args, columnValues(entity, r.meta.insertCols)...)
+				if returning {
+					args = append(args, int64(start+i))
+				}
+			}
+
+			query := fmt.Sprintf(
+				"INSERT INTO %s (%s) VALUES %s",
+				target, strings.Join(cols, ", "), strings.Join(tuples, ", "),
+			)
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+
+		if !returning {
+			return nil
+		}
+		return r.moveStage(ctx, tx, batch)
+	})
+}
+
+// createStage는 auto 컬럼 값을 미리 생성해 둘 임시 스테이징 테이블을 만듭니다
+//
+// 대상 테이블의 기본값(SERIAL의 nextval, DEFAULT 등)을 그대로 복사하고,
+// IDENTITY 컬럼에는 대상 테이블과 같은 시퀀스의 nextval을 기본값으로 지정합니다
+func (r *Repository[T]) createStage(ctx context.Context, tx *sqlx.Tx) error {
+	stage := quoteIdent(bulkStageTable)
+	stmts := []string{
+		fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP", stage, r.table),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s BIGINT NOT NULL", stage, quoteIdent(bulkOrdColumn)),
+	}
+	for _, col := range r.meta.returnCols {
+		var seq sql.NullString
+		if err := tx.GetContext(ctx, &seq, "SELECT pg_get_serial_sequence($1, $2)", r.table.String(), col.name); err != nil {
+			return err
+		}
+		if seq.Valid {
+			stmts = append(stmts, fmt.Sprintf(
+				"ALTER TABLE %s ALTER COLUMN %s SET DEFAULT nextval(%s::regclass)",
+				stage, quoteIdent(col.name), pq.QuoteLiteral(seq.String),
+			))
+		}
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveStage는 스테이징 테이블의 행을 미리 생성된 auto 컬럼 값과 함께 대상 테이블로 옮기고
+// 그 값을 entity에 채웁니다
+//
+// INSERT ... RETURNING의 행 순서는 보장되지 않으므로 생성된 값은 스테이징 테이블에서
+// 삽입 순서(bulk_ord)대로 읽습니다. 대상 테이블의 BEFORE INSERT 트리거가 auto 컬럼을
+// 바꾸거나 auto 컬럼이 GENERATED ... STORED이면 사용할 수 없습니다
+func (r *Repository[T]) moveStage(ctx context.Context, tx *sqlx.Tx, batch []reflect.Value) error {
+	stage := quoteIdent(bulkStageTable)
+	cols := strings.Join(quoteColumns(slices.Concat(r.meta.insertCols, r.meta.returnCols)), ", ")
+
+	move := fmt.Sprintf(
+		"INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM %s",
+		r.table, cols, cols, stage,
+	)
+	if _, err := tx.ExecContext(ctx, move); err != nil {
+		return err
+	}
+
+	generated := fmt.Sprintf(
+		"SELECT %s FROM %s ORDER BY %s",
+		strings.Join(quoteColumns(r.meta.returnCols), ", "), stage, quoteIdent(bulkOrdColumn),
+	)
+	if err := r.scanGenerated(ctx, tx, batch, generated); err != nil {
+		return err
+	}
+
+	// 바깥 트랜잭션 안에서는 배치가 SAVEPOINT로 실행되어 ON COMMIT DROP이 다음 배치 전에 일어나지 않음
+	_, err := tx.ExecContext(ctx, "DROP TABLE "+stage)
+	return err
+}
+
+// scanGenerated는 query 결과를 순서대로 entity의 auto 컬럼에 채웁니다
+func (r *Repository[T]) scanGenerated(ctx context.Context, tx *sqlx.Tx, batch []reflect.Value, query string) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		if i >= len(batch) {
+			return fmt.Errorf("bulk insert staged more rows than inserted")
+		}
+		if err := rows.Scan(columnPointers(batch[i], r.meta.returnCols)...); err != nil {
+			return err
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if i != len(batch) {
+		return fmt.Errorf("bulk insert staged %d rows, inserted %d", i, len(batch))
+	}
+	return nil
+}
+
+// BulkInsertUsers는 사용자들을 대량 삽입하고 생성된 ID를 삽입 순서대로 반환합니다
+//
+// users의 각 원소에도 생성된 ID와 CreatedAt이 채워집니다
+func (c *Client) BulkInsertUsers(ctx context.Context, table Identifier, users []User, opts BulkOptions) ([]int64, error) {
+	repo, err := NewRepository[User](c, table)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*User, len(users))
+	for i := range users {
+		ptrs[i] = &users[i]
+	}
+
+	opts.ReturnGenerated = true
+	if _, err := repo.BulkInsert(ctx, slices.Values(ptrs), opts); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(users))
+	for i, u := range users {
+		ids[i] = int64(u.ID)
+	}
+	return ids, nil
+}
+
+// isCopyUnsupported는 COPY를 사용할 수 없어 INSERT로 대체해야 하는 에러인지 확인합니다
+func isCopyUnsupported(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	// 0A: feature_not_supported (예: 트랜잭션 풀링 프록시), 42501: insufficient_privilege
+	return pqErr.Code.Class() == "0A" || pqErr.Code == "42501"
+}
+
+// columnNames는 컬럼 이름 목록을 반환합니다
+func columnNames(cols []column) []string {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.name
+	}
+	return names
+}
+
+// quoteColumns는 인용된 컬럼 이름 목록을 반환합니다
+func quoteColumns(cols []column) []string {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = quoteIdent(col.name)
+	}
+	return names
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCopyUnsupported(t *testing.T) {
+	assert.True(t, isCopyUnsupported(&pq.Error{Code: "0A000"}))
+	assert.True(t, isCopyUnsupported(fmt.Errorf("wrapped: %w", &pq.Error{Code: "42501"})))
+	assert.False(t, isCopyUnsupported(&pq.Error{Code: "23505"}))
+	assert.False(t, isCopyUnsupported(fmt.Errorf("plain error")))
+	assert.False(t, isCopyUnsupported(nil))
+}
+
+func TestBulkInsertNilEntity(t *testing.T) {
+	repo, err := NewRepository[Order](nil, MustParseIdentifier("orders"))
+	require.NoError(t, err)
+
+	// nil 원소는 DB에 접근하기 전에 인덱스와 함께 에러로 반환
+	orders := []*Order{nil, {Customer: "ok", Amount: 1}}
+	result, err := repo.BulkInsert(context.Background(), slices.Values(orders), BulkOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "entity 0 is nil")
+	assert.Equal(t, int64(0), result.Inserted)
+}
+
+func TestPostgreSQLBulkInsertUsers(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+
+	users := make([]User, 2500)
+	for i := range users {
+		users[i] = User{
+			Name:  fmt.Sprintf("User %04d", i),
+			Email: fmt.Sprintf("user%04d@example.com", i),
+		}
+	}
+
+	// 배치별 진행 상황 기록
+	var progress []BulkProgress
+	ids, err := client.BulkInsertUsers(ctx, tableName, users, BulkOptions{
+		BatchSize: 1000,
+		Progress: func(p BulkProgress) {
+			progress = append(progress, p)
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, progress, 3)
+	assert.Equal(t, 1000, progress[0].Rows)
+	assert.Equal(t, 500, progress[2].Rows)
+	assert.Equal(t, int64(2500), progress[2].Total)
+	assert.True(t, progress[2].UsedCopy)
+
+	// 생성된 ID가 삽입 순서대로 반환되어야 함
+	require.Len(t, ids, 2500)
+	assert.IsIncreasing(t, ids)
+
+	user, err := client.GetUser(ctx, tableName, ids[1234])
+	require.NoError(t, err)
+	assert.Equal(t, "User 1234", user.Name)
+	assert.Equal(t, int(ids[1234]), users[1234].ID)
+
+	all, err := client.GetAllUsers(ctx, tableName)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2500)
+
+	// 모든 entity에 자신의 행에서 생성된 값이 채워져야 함
+	byID := make(map[int]User, len(all))
+	for _, u := range all {
+		byID[u.ID] = u
+	}
+	for _, u := range users {
+		assert.Equal(t, u.Email, byID[u.ID].Email)
+		assert.Equal(t, byID[u.ID].CreatedAt, u.CreatedAt)
+	}
+}
+
+func TestRepositoryBulkInsert(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	repo, err := NewRepository[Order](client, MustParseIdentifier("orders"))
+	require.NoError(t, err)
+
+	newOrders := func(n int) []*Order {
+		orders := make([]*Order, n)
+		for i := range orders {
+			orders[i] = &Order{Customer: fmt.Sprintf("customer-%d", i%7), Amount: i}
+		}
+		return orders
+	}
+
+	// COPY로 직접 삽입 (생성된 값은 돌려받지 않음)
+	copied := newOrders(300)
+	result, err := repo.BulkInsert(ctx, slices.Values(copied), BulkOptions{BatchSize: 128})
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), result.Inserted)
+	assert.Equal(t, 3, result.Batches)
+	assert.True(t, result.UsedCopy)
+	assert.Zero(t, copied[0].ID)
+
+	// 다중 행 INSERT로 삽입하고 생성된 ID 돌려받기
+	inserted := newOrders(200)
+	result, err = repo.BulkInsert(ctx, slices.Values(inserted), BulkOptions{
+		DisableCopy:     true,
+		ReturnGenerated: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), result.Inserted)
+	assert.False(t, result.UsedCopy)
+	for _, o := range inserted {
+		assert.Greater(t, o.ID, int64(0))
+	}
+
+	found, err := repo.Get(ctx, inserted[150].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 150, found.Amount)
+
+	all, err := repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 500)
+
+	// 제약 조건 위반 시 해당 배치는 롤백
+	bad := []*Order{{Customer: "ok", Amount: 1}, {Customer: "bad", Amount: -1}}
+	result, err = repo.BulkInsert(ctx, slices.Values(bad), BulkOptions{})
+	assert.ErrorIs(t, err, ErrCheckViolation)
+	assert.Equal(t, int64(0), result.Inserted)
+
+	all, err = repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 500)
+}
+
+func TestPostgreSQLBulkInsertInTransaction(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+
+	newUsers := func(prefix string, n int) []User {
+		users := make([]User, n)
+		for i := range users {
+			users[i] = User{
+				Name:  fmt.Sprintf("%s %d", prefix, i),
+				Email: fmt.Sprintf("%s%d@example.com", prefix, i),
+			}
+		}
+		return users
+	}
+
+	// 바깥 트랜잭션 안에서는 배치마다 SAVEPOINT로 실행되며 스테이징 테이블을 재사용
+	committed := newUsers("copy", 250)
+	inserted := newUsers("insert", 150)
+	err := client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		if _, err := client.BulkInsertUsers(ctx, tableName, committed, BulkOptions{BatchSize: 100}); err != nil {
+			return err
+		}
+		_, err := client.BulkInsertUsers(ctx, tableName, inserted, BulkOptions{BatchSize: 100, DisableCopy: true})
+		return err
+	})
+	require.NoError(t, err)
+
+	for _, u := range slices.Concat(committed, inserted) {
+		found, err := client.GetUser(ctx, tableName, int64(u.ID))
+		require.NoError(t, err)
+		assert.Equal(t, u.Name, found.Name)
+	}
+
+	// 바깥 트랜잭션이 롤백되면 모든 배치가 함께 롤백
+	errOuter := errors.New("outer failure")
+	err = client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		if _, err := client.BulkInsertUsers(ctx, tableName, newUsers("rollback", 250), BulkOptions{BatchSize: 100}); err != nil {
+			return err
+		}
+		return errOuter
+	})
+	assert.ErrorIs(t, err, errOuter)
+
+	all, err := client.GetAllUsers(ctx, tableName)
+	require.NoError(t, err)
+	assert.Len(t, all, 400)
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 
@@ -11,25 +12,43 @@ import (
 
 // Client는 PostgreSQL 클라이언트를 래핑합니다
 type Client struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	cursorKey []byte
 }
 
 // User는 사용자 정보를 나타냅니다
 type User struct {
-	ID        int       `db:"id"`
-	Name      string    `db:"name"`
-	Email     string    `db:"email"`
-	CreatedAt string    `db:"created_at"`
+	ID        int    `db:"id,pk,auto"`
+	Name      string `db:"name"`
+	Email     string `db:"email"`
+	CreatedAt string `db:"created_at,auto"`
 }
 
 // NewClient는 새로운 PostgreSQL 클라이언트를 생성합니다
-func NewClient(connStr string) (*Client, error) {
-	db, err := sqlx.Connect("postgres", connStr)
+//
+// opts로 연결 풀 크기, 연결 수명, 연결 재시도, statement_timeout 등을 설정할 수 있습니다
+func NewClient(connStr string, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	db, err := connect(connStr, o)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
+	}
+
+	// 페이지네이션 커서 서명용 키 (WithCursorKey 또는 SetCursorKey로 변경 가능)
+	cursorKey := o.cursorKey
+	if len(cursorKey) == 0 {
+		cursorKey = make([]byte, 32)
+		if _, err := rand.Read(cursorKey); err != nil {
+			db.Close()
+			return nil, err
+		}
 	}
 
-	return &Client{db: db}, nil
+	return &Client{db: db, cursorKey: cursorKey}, nil
 }
 
 // Close는 데이터베이스 연결을 종료합니다
@@ -39,11 +58,15 @@ func (c *Client) Close() error {
 
 // Ping은 데이터베이스 연결을 확인합니다
 func (c *Client) Ping(ctx context.Context) error {
-	return c.db.PingContext(ctx)
+	return mapError(c.db.PingContext(ctx))
 }
 
 // CreateTable은 테이블을 생성합니다
-func (c *Client) CreateTable(ctx context.Context, tableName string) error {
+func (c *Client) CreateTable(ctx context.Context, table Identifier) error {
+	if err := table.validate(); err != nil {
+		return err
+	}
+
 	query := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s (
 			id SERIAL PRIMARY KEY,
@@ -51,132 +74,148 @@ func (c *Client) CreateTable(ctx context.Context, tableName string) error {
 			email VARCHAR(100) UNIQUE NOT NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)
-	`, tableName)
+	`, table)
 
-	_, err := c.db.ExecContext(ctx, query)
-	return err
+	_, err := c.conn(ctx).ExecContext(ctx, query)
+	return mapError(err)
 }
 
 // DropTable은 테이블을 삭제합니다
-func (c *Client) DropTable(ctx context.Context, tableName string) error {
-	query := fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName)
-	_, err := c.db.ExecContext(ctx, query)
-	return err
+func (c *Client) DropTable(ctx context.Context, table Identifier) error {
+	if err := table.validate(); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s", table)
+	_, err := c.conn(ctx).ExecContext(ctx, query)
+	return mapError(err)
 }
 
 // InsertUser는 사용자를 추가합니다
-func (c *Client) InsertUser(ctx context.Context, tableName, name, email string) (int64, error) {
+func (c *Client) InsertUser(ctx context.Context, table Identifier, name, email string) (int64, error) {
+	if err := table.validate(); err != nil {
+		return 0, err
+	}
+
 	query := fmt.Sprintf(
 		"INSERT INTO %s (name, email) VALUES ($1, $2) RETURNING id",
-		tableName,
+		table,
 	)
 
 	var id int64
-	err := c.db.QueryRowContext(ctx, query, name, email).Scan(&id)
-	return id, err
+	err := c.conn(ctx).QueryRowContext(ctx, query, name, email).Scan(&id)
+	return id, mapError(err)
 }
 
-// GetUser는 ID로 사용자를 조회합니다
-func (c *Client) GetUser(ctx context.Context, tableName string, id int64) (*User, error) {
-	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", tableName)
+// GetUser는 ID로 사용자를 조회합니다 (없으면 ErrNotFound)
+func (c *Client) GetUser(ctx context.Context, table Identifier, id int64) (*User, error) {
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", table)
 
 	var user User
-	err := c.db.GetContext(ctx, &user, query, id)
+	err := c.conn(ctx).GetContext(ctx, &user, query, id)
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, notFound("user", id)
 	}
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return &user, nil
 }
 
 // GetAllUsers는 모든 사용자를 조회합니다
-func (c *Client) GetAllUsers(ctx context.Context, tableName string) ([]User, error) {
-	query := fmt.Sprintf("SELECT * FROM %s ORDER BY id", tableName)
+func (c *Client) GetAllUsers(ctx context.Context, table Identifier) ([]User, error) {
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY id", table)
 
 	var users []User
-	err := c.db.SelectContext(ctx, &users, query)
-	return users, err
+	err := c.conn(ctx).SelectContext(ctx, &users, query)
+	return users, mapError(err)
 }
 
 // UpdateUser는 사용자 정보를 업데이트합니다
-func (c *Client) UpdateUser(ctx context.Context, tableName string, id int64, name, email string) error {
+func (c *Client) UpdateUser(ctx context.Context, table Identifier, id int64, name, email string) error {
+	if err := table.validate(); err != nil {
+		return err
+	}
+
 	query := fmt.Sprintf(
 		"UPDATE %s SET name = $1, email = $2 WHERE id = $3",
-		tableName,
+		table,
 	)
 
-	result, err := c.db.ExecContext(ctx, query, name, email, id)
+	result, err := c.conn(ctx).ExecContext(ctx, query, name, email, id)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user with id %d not found", id)
+		return notFound("user", id)
 	}
 
 	return nil
 }
 
 // DeleteUser는 사용자를 삭제합니다
-func (c *Client) DeleteUser(ctx context.Context, tableName string, id int64) error {
-	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", tableName)
+func (c *Client) DeleteUser(ctx context.Context, table Identifier, id int64) error {
+	if err := table.validate(); err != nil {
+		return err
+	}
 
-	result, err := c.db.ExecContext(ctx, query, id)
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", table)
+
+	result, err := c.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user with id %d not found", id)
+		return notFound("user", id)
 	}
 
 	return nil
 }
 
 // GetUsersByNamePattern은 이름 패턴으로 사용자를 조회합니다
-func (c *Client) GetUsersByNamePattern(ctx context.Context, tableName, pattern string) ([]User, error) {
-	query := fmt.Sprintf("SELECT * FROM %s WHERE name LIKE $1 ORDER BY id", tableName)
+func (c *Client) GetUsersByNamePattern(ctx context.Context, table Identifier, pattern string) ([]User, error) {
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE name LIKE $1 ORDER BY id", table)
 
 	var users []User
-	err := c.db.SelectContext(ctx, &users, query, pattern)
-	return users, err
+	err := c.conn(ctx).SelectContext(ctx, &users, query, pattern)
+	return users, mapError(err)
 }
 
 // BeginTransaction은 트랜잭션을 시작합니다
 func (c *Client) BeginTransaction(ctx context.Context) (*sqlx.Tx, error) {
-	return c.db.BeginTxx(ctx, nil)
+	tx, err := c.db.BeginTxx(ctx, nil)
+	return tx, mapError(err)
 }
 
 // ExecuteInTransaction은 트랜잭션 내에서 함수를 실행합니다
-func (c *Client) ExecuteInTransaction(ctx context.Context, fn func(*sqlx.Tx) error) error {
-	tx, err := c.BeginTransaction(ctx)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		}
-	}()
-
-	if err := fn(tx); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	return tx.Commit()
+//
+// fn에는 트랜잭션이 담긴 ctx가 전달되므로, 이 ctx로 호출한 Client 메서드와 다른 트랜잭션 헬퍼,
+// AfterCommit은 같은 트랜잭션을 사용합니다. ctx에 이미 트랜잭션이 있으면 SAVEPOINT로 중첩됩니다
+// (ExecuteInTransactionWithOptions 참고)
+func (c *Client) ExecuteInTransaction(ctx context.Context, fn TxFunc) error {
+	return c.ExecuteInTransactionWithOptions(ctx, nil, fn)
 }
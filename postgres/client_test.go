@@ -20,7 +20,7 @@ const (
 	dbPass = "testpass"
 )
 
-func setupPostgres(t *testing.T) (*Client, func()) {
+func setupPostgres(t *testing.T, opts ...Option) (*Client, func()) {
 	ctx := context.Background()
 
 	// PostgreSQL 컨테이너 시작
@@ -42,13 +42,20 @@ func setupPostgres(t *testing.T) (*Client, func()) {
 	require.NoError(t, err)
 
 	// 클라이언트 생성
-	client, err := NewClient(connStr)
+	client, err := NewClient(connStr, opts...)
 	require.NoError(t, err)
 
 	// 연결 확인
 	err = client.Ping(ctx)
 	require.NoError(t, err)
 
+	// 애플리케이션 스키마 마이그레이션 적용
+	migrator, err := NewMigrator(client, SchemaMigrations())
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
 	cleanup := func() {
 		client.Close()
 		if err := testcontainers.TerminateContainer(postgresContainer); err != nil {
@@ -64,7 +71,7 @@ func TestPostgreSQLCreateTable(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
+	tableName := MustParseIdentifier("legacy_users")
 
 	// 테이블 생성
 	err := client.CreateTable(ctx, tableName)
@@ -78,7 +85,7 @@ func TestPostgreSQLCreateTable(t *testing.T) {
 			WHERE table_name = $1
 		)
 	`
-	err = client.db.QueryRowContext(ctx, query, tableName).Scan(&exists)
+	err = client.db.QueryRowContext(ctx, query, tableName.Name()).Scan(&exists)
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
@@ -88,11 +95,7 @@ func TestPostgreSQLInsertAndGet(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
-
-	// 테이블 생성
-	err := client.CreateTable(ctx, tableName)
-	require.NoError(t, err)
+	tableName := MustParseIdentifier("users")
 
 	// 사용자 추가
 	id, err := client.InsertUser(ctx, tableName, "John Doe", "john@example.com")
@@ -112,12 +115,9 @@ func TestPostgreSQLUpdate(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
-
-	// 테이블 생성 및 사용자 추가
-	err := client.CreateTable(ctx, tableName)
-	require.NoError(t, err)
+	tableName := MustParseIdentifier("users")
 
+	// 사용자 추가
 	id, err := client.InsertUser(ctx, tableName, "John Doe", "john@example.com")
 	require.NoError(t, err)
 
@@ -137,12 +137,9 @@ func TestPostgreSQLDelete(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
-
-	// 테이블 생성 및 사용자 추가
-	err := client.CreateTable(ctx, tableName)
-	require.NoError(t, err)
+	tableName := MustParseIdentifier("users")
 
+	// 사용자 추가
 	id, err := client.InsertUser(ctx, tableName, "John Doe", "john@example.com")
 	require.NoError(t, err)
 
@@ -152,7 +149,7 @@ func TestPostgreSQLDelete(t *testing.T) {
 
 	// 삭제 확인
 	user, err := client.GetUser(ctx, tableName, id)
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 	assert.Nil(t, user)
 }
 
@@ -161,11 +158,7 @@ func TestPostgreSQLGetAll(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
-
-	// 테이블 생성
-	err := client.CreateTable(ctx, tableName)
-	require.NoError(t, err)
+	tableName := MustParseIdentifier("users")
 
 	// 여러 사용자 추가
 	users := []struct {
@@ -193,11 +186,7 @@ func TestPostgreSQLWhereClause(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
-
-	// 테이블 생성
-	err := client.CreateTable(ctx, tableName)
-	require.NoError(t, err)
+	tableName := MustParseIdentifier("users")
 
 	// 여러 사용자 추가
 	users := []struct {
@@ -225,14 +214,10 @@ func TestPostgreSQLTransaction(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
-
-	// 테이블 생성
-	err := client.CreateTable(ctx, tableName)
-	require.NoError(t, err)
+	tableName := MustParseIdentifier("users")
 
 	// 트랜잭션 성공 케이스
-	err = client.ExecuteInTransaction(ctx, func(tx *sqlx.Tx) error {
+	err := client.ExecuteInTransaction(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
 		query := fmt.Sprintf(
 			"INSERT INTO %s (name, email) VALUES ($1, $2)",
 			tableName,
@@ -253,7 +238,7 @@ func TestPostgreSQLTransaction(t *testing.T) {
 	assert.Len(t, users, 2)
 
 	// 트랜잭션 롤백 케이스
-	err = client.ExecuteInTransaction(ctx, func(tx *sqlx.Tx) error {
+	err = client.ExecuteInTransaction(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
 		query := fmt.Sprintf(
 			"INSERT INTO %s (name, email) VALUES ($1, $2)",
 			tableName,
@@ -263,11 +248,16 @@ func TestPostgreSQLTransaction(t *testing.T) {
 			return err
 		}
 
+		// 콜백의 ctx로 호출한 헬퍼도 같은 트랜잭션에서 실행되어 함께 롤백
+		if _, err := client.InsertUser(ctx, tableName, "Carol Helper", "carol@example.com"); err != nil {
+			return err
+		}
+
 		// 중복 이메일로 인한 에러 발생
 		_, err = tx.ExecContext(ctx, query, "Another User", "john@example.com")
 		return err
 	})
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrDuplicate)
 
 	// 롤백 확인 - 여전히 2명만 있어야 함
 	users, err = client.GetAllUsers(ctx, tableName)
@@ -280,7 +270,7 @@ func TestPostgreSQLDropTable(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	tableName := "users"
+	tableName := MustParseIdentifier("legacy_users")
 
 	// 테이블 생성
 	err := client.CreateTable(ctx, tableName)
@@ -298,7 +288,7 @@ func TestPostgreSQLDropTable(t *testing.T) {
 			WHERE table_name = $1
 		)
 	`
-	err = client.db.QueryRowContext(ctx, query, tableName).Scan(&exists)
+	err = client.db.QueryRowContext(ctx, query, tableName.Name()).Scan(&exists)
 	assert.NoError(t, err)
 	assert.False(t, exists)
 }
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/lib/pq"
+)
+
+// errors.Is로 비교할 수 있는 에러 종류
+var (
+	// ErrNotFound는 조회, 수정, 삭제 대상 행이 없을 때 반환됩니다
+	ErrNotFound = errors.New("not found")
+	// ErrDuplicate는 UNIQUE 제약 조건 위반(23505)입니다
+	ErrDuplicate = errors.New("duplicate key")
+	// ErrForeignKey는 외래 키 제약 조건 위반(23503)입니다
+	ErrForeignKey = errors.New("foreign key violation")
+	// ErrCheckViolation은 CHECK 제약 조건 위반(23514)입니다
+	ErrCheckViolation = errors.New("check constraint violation")
+	// ErrConnection은 DB 연결 실패 또는 연결 끊김입니다
+	ErrConnection = errors.New("connection error")
+)
+
+// Error는 SQLSTATE 코드 등으로 분류된 DB 에러입니다
+//
+// errors.Is(err, ErrDuplicate)처럼 종류를 비교할 수 있고,
+// errors.As로 원본 *pq.Error도 꺼낼 수 있습니다
+type Error struct {
+	// Kind는 ErrDuplicate, ErrForeignKey 등 에러 종류입니다
+	Kind error
+	// Code는 SQLSTATE 코드입니다 (연결 에러 등에서는 빈 문자열)
+	Code string
+	// Constraint는 위반된 제약 조건 이름입니다
+	Constraint string
+	// Table은 에러가 발생한 테이블 이름입니다
+	Table string
+	// Err는 원본 에러입니다
+	Err error
+}
+
+// Error는 에러 메시지를 반환합니다
+func (e *Error) Error() string {
+	if e.Constraint != "" {
+		return fmt.Sprintf("%v (constraint %s): %v", e.Kind, e.Constraint, e.Err)
+	}
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+// Is는 errors.Is에서 에러 종류와 비교할 수 있도록 합니다
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap은 원본 에러를 반환합니다
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// mapError는 드라이버 에러를 SQLSTATE 코드에 따라 분류된 *Error로 변환합니다
+//
+// 분류할 수 없는 에러는 그대로 반환합니다
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mapped *Error
+	if errors.As(err, &mapped) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{Kind: ErrNotFound, Err: err}
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		kind := pqErrorKind(pqErr)
+		if kind == nil {
+			return err
+		}
+		return &Error{
+			Kind:       kind,
+			Code:       string(pqErr.Code),
+			Constraint: pqErr.Constraint,
+			Table:      pqErr.Table,
+			Err:        err,
+		}
+	}
+
+	if isConnectionError(err) {
+		return &Error{Kind: ErrConnection, Err: err}
+	}
+
+	return err
+}
+
+// pqErrorKind는 SQLSTATE 코드에 해당하는 에러 종류를 반환합니다
+func pqErrorKind(err *pq.Error) error {
+	switch err.Code {
+	case "23505":
+		return ErrDuplicate
+	case "23503":
+		return ErrForeignKey
+	case "23514":
+		return ErrCheckViolation
+	case "57P01", "57P02", "57P03":
+		// admin_shutdown, crash_shutdown, cannot_connect_now
+		return ErrConnection
+	}
+
+	if err.Code.Class() == "08" {
+		// connection_exception
+		return ErrConnection
+	}
+	return nil
+}
+
+// isConnectionError는 네트워크 또는 드라이버 수준의 연결 에러인지 확인합니다
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// notFound는 키에 해당하는 행이 없다는 ErrNotFound 에러를 만듭니다
+func notFound(what string, key interface{}) error {
+	return fmt.Errorf("%s with id %v: %w", what, key, ErrNotFound)
+}
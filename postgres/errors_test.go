@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"no rows", sql.ErrNoRows, ErrNotFound},
+		{"unique", &pq.Error{Code: "23505", Constraint: "users_email_key"}, ErrDuplicate},
+		{"foreign key", &pq.Error{Code: "23503"}, ErrForeignKey},
+		{"check", &pq.Error{Code: "23514"}, ErrCheckViolation},
+		{"connection failure", &pq.Error{Code: "08006"}, ErrConnection},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, ErrConnection},
+		{"bad conn", driver.ErrBadConn, ErrConnection},
+		{"dial", &net.OpError{Op: "dial", Err: errors.New("refused")}, ErrConnection},
+	}
+
+	for _, tt := range tests {
+		err := mapError(fmt.Errorf("wrapped: %w", tt.err))
+		assert.ErrorIs(t, err, tt.kind, tt.name)
+
+		var dbErr *Error
+		assert.True(t, errors.As(err, &dbErr), tt.name)
+	}
+
+	// 원본 *pq.Error도 꺼낼 수 있어야 함
+	err := mapError(&pq.Error{Code: "23505", Constraint: "users_email_key"})
+	var pqErr *pq.Error
+	require.True(t, errors.As(err, &pqErr))
+	assert.Equal(t, "users_email_key", err.(*Error).Constraint)
+
+	// 분류할 수 없는 에러는 그대로 반환
+	plain := errors.New("plain")
+	assert.Same(t, plain, mapError(plain))
+	syntax := &pq.Error{Code: "42601"}
+	assert.Same(t, syntax, mapError(syntax))
+	assert.NoError(t, mapError(nil))
+
+	// 이미 분류된 에러는 다시 감싸지 않음
+	assert.Same(t, err, mapError(err))
+}
+
+func TestPostgreSQLErrorTaxonomy(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+
+	id, err := client.InsertUser(ctx, tableName, "John Doe", "john@example.com")
+	require.NoError(t, err)
+
+	// 중복 이메일
+	_, err = client.InsertUser(ctx, tableName, "Johnny", "john@example.com")
+	assert.ErrorIs(t, err, ErrDuplicate)
+	var dbErr *Error
+	require.True(t, errors.As(err, &dbErr))
+	assert.Equal(t, "users_email_key", dbErr.Constraint)
+	assert.Equal(t, "23505", dbErr.Code)
+
+	other, err := client.InsertUser(ctx, tableName, "Jane Doe", "jane@example.com")
+	require.NoError(t, err)
+	err = client.UpdateUser(ctx, tableName, other, "Jane Doe", "john@example.com")
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	// 없는 사용자
+	_, err = client.GetUser(ctx, tableName, 9999)
+	assert.ErrorIs(t, err, ErrNotFound)
+	err = client.UpdateUser(ctx, tableName, 9999, "Nobody", "nobody@example.com")
+	assert.ErrorIs(t, err, ErrNotFound)
+	err = client.DeleteUser(ctx, tableName, 9999)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 외래 키와 CHECK 제약 조건
+	type userOrder struct {
+		ID       int64  `db:"order_id,pk,auto"`
+		Customer string `db:"customer"`
+		Amount   int    `db:"amount"`
+		UserID   int64  `db:"user_id"`
+	}
+	orders, err := NewRepository[userOrder](client, MustParseIdentifier("orders"))
+	require.NoError(t, err)
+
+	err = orders.Insert(ctx, &userOrder{Customer: "john", Amount: 10, UserID: id})
+	assert.NoError(t, err)
+
+	err = orders.Insert(ctx, &userOrder{Customer: "ghost", Amount: 10, UserID: 9999})
+	assert.ErrorIs(t, err, ErrForeignKey)
+
+	err = orders.Insert(ctx, &userOrder{Customer: "john", Amount: -5, UserID: id})
+	assert.ErrorIs(t, err, ErrCheckViolation)
+}
+
+func TestPostgreSQLConnectionError(t *testing.T) {
+	// 아무것도 수신하지 않는 포트로 연결
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	connStr := fmt.Sprintf("postgres://user:pass@%s/db?sslmode=disable&connect_timeout=2", addr)
+	_, err = NewClient(connStr)
+	assert.ErrorIs(t, err, ErrConnection)
+
+	// 서버가 연결을 종료한 경우 (57P01)
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := client.db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_terminate_backend(pg_backend_pid())")
+	assert.ErrorIs(t, mapError(err), ErrConnection)
+}
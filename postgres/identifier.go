@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxIdentifierLength는 PostgreSQL 식별자의 최대 길이입니다 (NAMEDATALEN - 1)
+const maxIdentifierLength = 63
+
+// ErrInvalidIdentifier는 유효하지 않은 식별자를 나타내는 에러입니다
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// IdentifierError는 식별자 검증 실패 정보를 담는 에러입니다
+type IdentifierError struct {
+	Value  string
+	Reason string
+}
+
+// Error는 에러 메시지를 반환합니다
+func (e *IdentifierError) Error() string {
+	return fmt.Sprintf("invalid identifier %q: %s", e.Value, e.Reason)
+}
+
+// Is는 errors.Is에서 ErrInvalidIdentifier와 비교할 수 있도록 합니다
+func (e *IdentifierError) Is(target error) bool {
+	return target == ErrInvalidIdentifier
+}
+
+// Identifier는 검증된 테이블 식별자를 나타냅니다
+//
+// ParseIdentifier 또는 NewIdentifier로만 생성할 수 있으며
+// SQL에 사용될 때는 항상 큰따옴표로 인용됩니다
+type Identifier struct {
+	schema string
+	name   string
+}
+
+// ParseIdentifier는 "table" 또는 "schema.table" 형식의 문자열을 검증하여 Identifier를 생성합니다
+func ParseIdentifier(s string) (Identifier, error) {
+	parts := strings.Split(s, ".")
+	switch len(parts) {
+	case 1:
+		return NewIdentifier("", parts[0])
+	case 2:
+		if parts[0] == "" {
+			return Identifier{}, &IdentifierError{Value: s, Reason: "empty schema"}
+		}
+		return NewIdentifier(parts[0], parts[1])
+	default:
+		return Identifier{}, &IdentifierError{Value: s, Reason: "too many dot-separated parts"}
+	}
+}
+
+// MustParseIdentifier는 ParseIdentifier와 같지만 실패 시 panic합니다
+func MustParseIdentifier(s string) Identifier {
+	id, err := ParseIdentifier(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// NewIdentifier는 스키마와 이름을 검증하여 Identifier를 생성합니다 (schema는 생략 가능)
+func NewIdentifier(schema, name string) (Identifier, error) {
+	if schema != "" {
+		if err := validateIdentifierPart(schema); err != nil {
+			return Identifier{}, err
+		}
+	}
+	if err := validateIdentifierPart(name); err != nil {
+		return Identifier{}, err
+	}
+	return Identifier{schema: schema, name: name}, nil
+}
+
+// validateIdentifierPart는 식별자 한 부분이 안전한 문자로만 이루어졌는지 확인합니다
+func validateIdentifierPart(part string) error {
+	if part == "" {
+		return &IdentifierError{Value: part, Reason: "empty name"}
+	}
+	if len(part) > maxIdentifierLength {
+		return &IdentifierError{
+			Value:  part,
+			Reason: fmt.Sprintf("longer than %d bytes", maxIdentifierLength),
+		}
+	}
+
+	for i, r := range part {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return &IdentifierError{
+				Value:  part,
+				Reason: fmt.Sprintf("unexpected character %q at position %d", r, i),
+			}
+		}
+	}
+	return nil
+}
+
+// quoteIdent는 PostgreSQL 방식으로 식별자를 큰따옴표로 인용합니다
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// Schema는 스키마 이름을 반환합니다 (지정되지 않았으면 빈 문자열)
+func (id Identifier) Schema() string {
+	return id.schema
+}
+
+// Name은 테이블 이름을 반환합니다
+func (id Identifier) Name() string {
+	return id.name
+}
+
+// IsZero는 Identifier가 초기화되지 않았는지 확인합니다
+func (id Identifier) IsZero() bool {
+	return id.name == ""
+}
+
+// String은 SQL에 그대로 사용할 수 있는 인용된 식별자를 반환합니다
+func (id Identifier) String() string {
+	if id.schema == "" {
+		return quoteIdent(id.name)
+	}
+	return quoteIdent(id.schema) + "." + quoteIdent(id.name)
+}
+
+// validate는 초기화되지 않은 Identifier를 거부합니다
+func (id Identifier) validate() error {
+	if id.IsZero() {
+		return &IdentifierError{Value: "", Reason: "zero identifier"}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		input  string
+		quoted string
+	}{
+		{"users", `"users"`},
+		{"Users_2024", `"Users_2024"`},
+		{"public.users", `"public"."users"`},
+		{"_audit.log_rows", `"_audit"."log_rows"`},
+	}
+
+	for _, tt := range tests {
+		id, err := ParseIdentifier(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.quoted, id.String())
+	}
+
+	id := MustParseIdentifier("app.orders")
+	assert.Equal(t, "app", id.Schema())
+	assert.Equal(t, "orders", id.Name())
+}
+
+func TestParseIdentifierRejectsUnsafeInput(t *testing.T) {
+	inputs := []string{
+		"",
+		"users; DROP TABLE users",
+		`users"--`,
+		"1users",
+		"a.b.c",
+		".users",
+		"users.",
+		"user name",
+		strings.Repeat("a", maxIdentifierLength+1),
+	}
+
+	for _, input := range inputs {
+		_, err := ParseIdentifier(input)
+		assert.ErrorIs(t, err, ErrInvalidIdentifier, input)
+
+		var idErr *IdentifierError
+		assert.True(t, errors.As(err, &idErr), input)
+	}
+
+	assert.Panics(t, func() { MustParseIdentifier("bad-name") })
+}
+
+func TestClientRejectsZeroIdentifier(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{}
+
+	// DB에 접근하기 전에 거부되어야 함
+	err := client.CreateTable(ctx, Identifier{})
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+
+	_, err = client.InsertUser(ctx, Identifier{}, "John Doe", "john@example.com")
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+
+	_, err = client.GetAllUsers(ctx, Identifier{})
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+}
@@ -0,0 +1,325 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var schemaFiles embed.FS
+
+// DefaultMigrationsTable은 적용된 마이그레이션 버전을 기록하는 기본 테이블 이름입니다
+const DefaultMigrationsTable = "schema_migrations"
+
+// migrationFilePattern은 "0001_create_users.up.sql" 형식의 파일 이름과 일치합니다
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+// SchemaMigrations는 애플리케이션 스키마 마이그레이션 파일을 반환합니다
+func SchemaMigrations() fs.FS {
+	sub, err := fs.Sub(schemaFiles, "migrations")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Migration은 하나의 버전에 해당하는 up/down SQL을 나타냅니다
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus는 마이그레이션의 적용 상태를 나타냅니다
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator는 fs.FS에서 읽은 마이그레이션을 순서대로 적용하거나 되돌립니다
+type Migrator struct {
+	client     *Client
+	migrations []Migration
+	table      Identifier
+	lockKey    int64
+}
+
+// MigratorOption은 Migrator 설정을 변경합니다
+type MigratorOption func(*Migrator)
+
+// WithMigrationsTable은 버전 기록 테이블을 지정합니다
+func WithMigrationsTable(table Identifier) MigratorOption {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithLockKey는 advisory lock에 사용할 키를 지정합니다
+func WithLockKey(key int64) MigratorOption {
+	return func(m *Migrator) {
+		m.lockKey = key
+	}
+}
+
+// NewMigrator는 fsys의 최상위 디렉토리에서 마이그레이션 파일을 읽어 Migrator를 생성합니다
+func NewMigrator(client *Client, fsys fs.FS, opts ...MigratorOption) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{
+		client:     client,
+		migrations: migrations,
+		table:      MustParseIdentifier(DefaultMigrationsTable),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if err := m.table.validate(); err != nil {
+		return nil, err
+	}
+	if m.lockKey == 0 {
+		h := fnv.New64a()
+		h.Write([]byte(m.table.String()))
+		m.lockKey = int64(h.Sum64())
+	}
+
+	return m, nil
+}
+
+// LoadMigrations는 fsys에서 마이그레이션 파일을 읽어 버전 순으로 정렬합니다
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrations는 로드된 마이그레이션 목록을 반환합니다
+func (m *Migrator) Migrations() []Migration {
+	return append([]Migration(nil), m.migrations...)
+}
+
+// Up은 적용되지 않은 모든 마이그레이션을 버전 순으로 적용하고 적용한 개수를 반환합니다
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			insert := fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", m.table)
+			err := m.runInTx(ctx, conn, migration.Up, insert, migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// DownTo는 version보다 큰 적용된 마이그레이션을 역순으로 되돌리고 되돌린 개수를 반환합니다
+//
+// version이 0이면 모든 마이그레이션을 되돌립니다
+func (m *Migrator) DownTo(ctx context.Context, version int64) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[int64]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = true
+		}
+		for v := range applied {
+			if v > version && !known[v] {
+				return fmt.Errorf("applied migration %d has no source file", v)
+			}
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version <= version {
+				break
+			}
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			del := fmt.Sprintf("DELETE FROM %s WHERE version = $1", m.table)
+			if err := m.runInTx(ctx, conn, migration.Down, del, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status는 각 마이그레이션의 적용 여부를 버전 순으로 반환합니다
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			appliedAt, ok := applied[migration.Version]
+			statuses = append(statuses, MigrationStatus{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock은 전용 연결에서 advisory lock을 잡은 상태로 fn을 실행합니다
+func (m *Migrator) withLock(ctx context.Context, fn func(*sqlx.Conn) error) error {
+	conn, err := m.client.db.Connx(ctx)
+	if err != nil {
+		return mapError(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", mapError(err))
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", m.lockKey)
+
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`, m.table)
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return mapError(err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions는 기록 테이블에서 적용된 버전과 적용 시각을 조회합니다
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sqlx.Conn) (map[int64]time.Time, error) {
+	query := fmt.Sprintf("SELECT version, applied_at FROM %s", m.table)
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, mapError(err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, mapError(rows.Err())
+}
+
+// runInTx는 마이그레이션 SQL과 버전 기록 쿼리를 하나의 트랜잭션으로 실행합니다
+func (m *Migrator) runInTx(ctx context.Context, conn *sqlx.Conn, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return mapError(err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		tx.Rollback()
+		return mapError(err)
+	}
+
+	return mapError(tx.Commit())
+}
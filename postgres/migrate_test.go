@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":         {Data: []byte("CREATE INDEX a_idx ON a (id);")},
+		"0002_add_index.down.sql":       {Data: []byte("DROP INDEX a_idx;")},
+		"0001_create_a.up.sql":          {Data: []byte("CREATE TABLE a (id INT);")},
+		"0001_create_a.down.sql":        {Data: []byte("DROP TABLE a;")},
+		"0010_seed_without_down.up.sql": {Data: []byte("INSERT INTO a VALUES (1);")},
+		"README.md":                     {Data: []byte("ignored")},
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_a", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE a;", migrations[0].Down)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, int64(10), migrations[2].Version)
+	assert.Empty(t, migrations[2].Down)
+
+	// 잘못된 파일 이름
+	_, err = LoadMigrations(fstest.MapFS{"create_a.sql": {Data: []byte("")}})
+	assert.Error(t, err)
+
+	// up 파일 누락
+	_, err = LoadMigrations(fstest.MapFS{"0001_a.down.sql": {Data: []byte("DROP TABLE a;")}})
+	assert.Error(t, err)
+
+	// 애플리케이션 스키마는 항상 로드 가능해야 함
+	schema, err := LoadMigrations(SchemaMigrations())
+	require.NoError(t, err)
+	assert.NotEmpty(t, schema)
+}
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	migrator, err := NewMigrator(client, SchemaMigrations())
+	require.NoError(t, err)
+	total := len(migrator.Migrations())
+
+	// setupPostgres에서 이미 모두 적용됨
+	applied, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, total)
+	for _, s := range statuses {
+		assert.True(t, s.Applied, s.Name)
+		assert.False(t, s.AppliedAt.IsZero(), s.Name)
+	}
+
+	// 첫 번째 버전까지 되돌리기
+	first := statuses[0].Version
+	reverted, err := migrator.DownTo(ctx, first)
+	assert.NoError(t, err)
+	assert.Equal(t, total-1, reverted)
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	for _, s := range statuses[1:] {
+		assert.False(t, s.Applied, s.Name)
+	}
+
+	// 모두 되돌린 후 users 테이블이 없어야 함
+	_, err = migrator.DownTo(ctx, 0)
+	assert.NoError(t, err)
+
+	var exists bool
+	err = client.db.QueryRowContext(ctx, "SELECT to_regclass('users') IS NOT NULL").Scan(&exists)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// 다시 적용
+	applied, err = migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, total, applied)
+}
+
+func TestMigratorFailedMigrationRollsBack(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	fsys := fstest.MapFS{
+		"0001_create_widgets.up.sql": {Data: []byte("CREATE TABLE widgets (id INT);")},
+		"0002_broken.up.sql":         {Data: []byte("CREATE TABLE gadgets (id INT PRIMARY KEY); INSERT INTO gadgets VALUES (1), (1);")},
+	}
+
+	migrator, err := NewMigrator(client, fsys, WithMigrationsTable(MustParseIdentifier("widget_migrations")))
+	require.NoError(t, err)
+
+	// DB 에러는 다른 Client 메서드와 같이 *Error로 매핑
+	applied, err := migrator.Up(ctx)
+	assert.ErrorIs(t, err, ErrDuplicate)
+	var pgErr *Error
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "gadgets", pgErr.Table)
+	assert.Contains(t, err.Error(), "migration 2_broken up")
+	assert.Equal(t, 1, applied)
+
+	// 실패한 마이그레이션은 기록되지 않고 스키마 변경도 롤백되어야 함
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+
+	var exists bool
+	err = client.db.QueryRowContext(ctx, "SELECT to_regclass('gadgets') IS NOT NULL").Scan(&exists)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestMigratorConcurrentUp(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	fsys := fstest.MapFS{
+		"0001_create_events.up.sql":  {Data: []byte("CREATE TABLE events (id SERIAL PRIMARY KEY);")},
+		"0002_add_event_name.up.sql": {Data: []byte("ALTER TABLE events ADD COLUMN name TEXT;")},
+		"0003_add_event_time.up.sql": {Data: []byte("ALTER TABLE events ADD COLUMN at TIMESTAMPTZ;")},
+	}
+	table := WithMigrationsTable(MustParseIdentifier("event_migrations"))
+
+	// 여러 프로세스가 동시에 마이그레이션하는 상황 재현
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	total := 0
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			migrator, err := NewMigrator(client, fsys, table)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			applied, err := migrator.Up(ctx)
+			assert.NoError(t, err)
+
+			mu.Lock()
+			total += applied
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// 각 마이그레이션은 정확히 한 번만 적용되어야 함
+	assert.Equal(t, 3, total)
+}
@@ -0,0 +1,5 @@
+CREATE TABLE orders (
+    order_id BIGSERIAL PRIMARY KEY,
+    customer VARCHAR(100) NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount >= 0)
+);
@@ -0,0 +1,2 @@
+DROP TABLE counter_flushes;
+DROP TABLE page_views;
@@ -0,0 +1,9 @@
+CREATE TABLE page_views (
+    page_id VARCHAR(255) PRIMARY KEY,
+    views BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE counter_flushes (
+    batch_id VARCHAR(64) PRIMARY KEY,
+    flushed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
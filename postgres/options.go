@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// defaultConnectRetryInterval은 연결 재시도 간격의 기본값입니다
+const defaultConnectRetryInterval = 200 * time.Millisecond
+
+// options는 NewClient에 적용되는 설정입니다
+type options struct {
+	maxOpenConns     int
+	maxIdleConns     *int
+	connMaxLifetime  time.Duration
+	connMaxIdleTime  time.Duration
+	connectTimeout   time.Duration
+	retryUntil       time.Duration
+	retryInterval    time.Duration
+	statementTimeout time.Duration
+	cursorKey        []byte
+}
+
+// Option은 NewClient의 연결 풀 및 연결 동작을 설정합니다
+type Option func(*options)
+
+// WithMaxOpenConns는 최대 열린 연결 수를 설정합니다 (0 이하면 무제한)
+func WithMaxOpenConns(n int) Option {
+	return func(o *options) {
+		o.maxOpenConns = n
+	}
+}
+
+// WithMaxIdleConns는 유휴 상태로 유지할 최대 연결 수를 설정합니다 (0 이하면 유휴 연결을 유지하지 않음)
+//
+// 지정하지 않으면 database/sql의 기본값(2)을 사용합니다
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) {
+		o.maxIdleConns = &n
+	}
+}
+
+// WithConnMaxLifetime은 연결을 재사용할 수 있는 최대 시간을 설정합니다
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.connMaxLifetime = d
+	}
+}
+
+// WithConnMaxIdleTime은 연결이 유휴 상태로 남아 있을 수 있는 최대 시간을 설정합니다
+func WithConnMaxIdleTime(d time.Duration) Option {
+	return func(o *options) {
+		o.connMaxIdleTime = d
+	}
+}
+
+// WithConnectTimeout은 연결 시도 한 번의 제한 시간을 설정합니다
+func WithConnectTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.connectTimeout = d
+	}
+}
+
+// WithConnectRetry는 DB가 준비될 때까지 interval 간격으로 최대 maxWait 동안 연결을 재시도합니다
+func WithConnectRetry(maxWait, interval time.Duration) Option {
+	return func(o *options) {
+		o.retryUntil = maxWait
+		o.retryInterval = interval
+	}
+}
+
+// WithStatementTimeout은 세션의 statement_timeout을 설정합니다
+func WithStatementTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.statementTimeout = d
+	}
+}
+
+// WithCursorKey는 페이지네이션 커서 서명 키를 지정합니다 (SetCursorKey 참고)
+func WithCursorKey(key []byte) Option {
+	return func(o *options) {
+		o.cursorKey = append([]byte(nil), key...)
+	}
+}
+
+// Stats는 연결 풀 통계를 반환합니다
+func (c *Client) Stats() sql.DBStats {
+	return c.db.Stats()
+}
+
+// buildDSN은 연결 문자열에 런타임 파라미터를 추가합니다
+//
+// URL 형식(postgres://)은 key=value 형식으로 변환한 뒤 추가합니다
+func buildDSN(connStr string, o *options) (string, error) {
+	if o.statementTimeout <= 0 {
+		return connStr, nil
+	}
+
+	dsn := connStr
+	if strings.HasPrefix(connStr, "postgres://") || strings.HasPrefix(connStr, "postgresql://") {
+		var err error
+		dsn, err = pq.ParseURL(connStr)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// lib/pq는 알 수 없는 파라미터를 서버 런타임 파라미터로 전달합니다
+	return fmt.Sprintf("%s statement_timeout=%d", dsn, o.statementTimeout.Milliseconds()), nil
+}
+
+// connect는 DB를 열고 풀 설정을 적용한 뒤 준비될 때까지 Ping합니다
+func connect(connStr string, o *options) (*sqlx.DB, error) {
+	dsn, err := buildDSN(connStr, o)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(o.maxOpenConns)
+	if o.maxIdleConns != nil {
+		db.SetMaxIdleConns(*o.maxIdleConns)
+	}
+	db.SetConnMaxLifetime(o.connMaxLifetime)
+	db.SetConnMaxIdleTime(o.connMaxIdleTime)
+
+	interval := o.retryInterval
+	if interval <= 0 {
+		interval = defaultConnectRetryInterval
+	}
+	deadline := time.Now().Add(o.retryUntil)
+
+	for {
+		err = pingOnce(db, o.connectTimeout)
+		if err == nil {
+			return db, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			db.Close()
+			return nil, err
+		}
+		time.Sleep(interval)
+	}
+}
+
+// pingOnce는 timeout 내에서 한 번 Ping합니다 (timeout이 0이면 제한 없음)
+func pingOnce(db *sqlx.DB, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return db.PingContext(ctx)
+}
@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+const (
+	// DefaultPageSize는 페이지 크기를 지정하지 않았을 때 사용하는 값입니다
+	DefaultPageSize = 100
+	// MaxPageSize는 한 페이지에서 조회할 수 있는 최대 행 수입니다
+	MaxPageSize = 1000
+)
+
+// ErrInvalidCursor는 커서 토큰이 손상되었거나 다른 조회에서 발급된 경우의 에러입니다
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// UserPage는 사용자 목록의 한 페이지를 나타냅니다
+type UserPage struct {
+	Users []User
+	// NextCursor는 다음 페이지를 조회할 때 사용하는 토큰입니다 (마지막 페이지이면 빈 문자열)
+	NextCursor string
+}
+
+// cursorPayload는 커서 토큰에 서명되어 담기는 정보입니다
+type cursorPayload struct {
+	After int64  `json:"a"`
+	Scope string `json:"s"`
+}
+
+// SetCursorKey는 커서 토큰 서명에 사용할 키를 지정합니다
+//
+// 여러 인스턴스가 같은 커서를 주고받아야 할 때 NewClient 직후에 호출합니다
+func (c *Client) SetCursorKey(key []byte) {
+	c.cursorKey = append([]byte(nil), key...)
+}
+
+// GetUsersPage는 id 기준 키셋 페이지네이션으로 사용자 한 페이지를 조회합니다
+//
+// cursor가 빈 문자열이면 첫 페이지를 조회합니다
+func (c *Client) GetUsersPage(ctx context.Context, table Identifier, cursor string, pageSize int) (*UserPage, error) {
+	return c.usersPage(ctx, table, nil, cursor, pageSize)
+}
+
+// GetUsersByNamePatternPage는 이름 패턴과 일치하는 사용자 한 페이지를 조회합니다
+func (c *Client) GetUsersByNamePatternPage(ctx context.Context, table Identifier, pattern, cursor string, pageSize int) (*UserPage, error) {
+	return c.usersPage(ctx, table, &pattern, cursor, pageSize)
+}
+
+// IterUsers는 모든 사용자를 id 순서로 순회하는 이터레이터를 반환합니다
+//
+// 내부적으로 pageSize 단위로 나누어 조회하므로 메모리 사용량이 일정합니다
+func (c *Client) IterUsers(ctx context.Context, table Identifier, pageSize int) iter.Seq2[User, error] {
+	return c.iterUsers(ctx, table, nil, pageSize)
+}
+
+// IterUsersByNamePattern은 이름 패턴과 일치하는 사용자를 id 순서로 순회하는 이터레이터를 반환합니다
+func (c *Client) IterUsersByNamePattern(ctx context.Context, table Identifier, pattern string, pageSize int) iter.Seq2[User, error] {
+	return c.iterUsers(ctx, table, &pattern, pageSize)
+}
+
+// usersPage는 커서를 검증하고 다음 페이지를 조회합니다
+func (c *Client) usersPage(ctx context.Context, table Identifier, pattern *string, cursor string, pageSize int) (*UserPage, error) {
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+
+	scope := cursorScope(table, pattern)
+	var after int64
+	if cursor != "" {
+		payload, err := c.decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if payload.Scope != scope {
+			return nil, fmt.Errorf("%w: issued for a different query", ErrInvalidCursor)
+		}
+		after = payload.After
+	}
+
+	limit := normalizePageSize(pageSize)
+	users, err := c.usersAfter(ctx, table, pattern, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &UserPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		last := page.Users[limit-1]
+		page.NextCursor, err = c.encodeCursor(cursorPayload{After: int64(last.ID), Scope: scope})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// iterUsers는 키셋 페이지네이션으로 사용자를 순회합니다
+func (c *Client) iterUsers(ctx context.Context, table Identifier, pattern *string, pageSize int) iter.Seq2[User, error] {
+	return func(yield func(User, error) bool) {
+		if err := table.validate(); err != nil {
+			yield(User{}, err)
+			return
+		}
+
+		limit := normalizePageSize(pageSize)
+		var after int64
+		for {
+			users, err := c.usersAfter(ctx, table, pattern, after, limit)
+			if err != nil {
+				yield(User{}, err)
+				return
+			}
+
+			for _, user := range users {
+				if !yield(user, nil) {
+					return
+				}
+			}
+
+			if len(users) < limit {
+				return
+			}
+			after = int64(users[len(users)-1].ID)
+		}
+	}
+}
+
+// usersAfter는 id가 after보다 큰 사용자를 최대 limit개 조회합니다
+func (c *Client) usersAfter(ctx context.Context, table Identifier, pattern *string, after int64, limit int) ([]User, error) {
+	var users []User
+	if pattern == nil {
+		query := fmt.Sprintf("SELECT * FROM %s WHERE id > $1 ORDER BY id LIMIT $2", table)
+		err := c.conn(ctx).SelectContext(ctx, &users, query, after, limit)
+		return users, mapError(err)
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id > $1 AND name LIKE $2 ORDER BY id LIMIT $3", table)
+	err := c.conn(ctx).SelectContext(ctx, &users, query, after, *pattern, limit)
+	return users, mapError(err)
+}
+
+// normalizePageSize는 페이지 크기를 1~MaxPageSize 범위로 보정합니다
+func normalizePageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		return MaxPageSize
+	}
+	return pageSize
+}
+
+// cursorScope는 커서를 발급한 조회(테이블, 패턴)를 식별하는 값을 생성합니다
+func cursorScope(table Identifier, pattern *string) string {
+	h := sha256.New()
+	h.Write([]byte(table.String()))
+	if pattern != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(*pattern))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// encodeCursor는 payload를 서명하여 "payload.signature" 형식의 토큰을 만듭니다
+func (c *Client) encodeCursor(payload cursorPayload) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.signCursor(encoded)), nil
+}
+
+// decodeCursor는 토큰의 서명을 검증하고 payload를 복원합니다
+func (c *Client) decodeCursor(cursor string) (*cursorPayload, error) {
+	encoded, signature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, c.signCursor(encoded)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidCursor)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return &payload, nil
+}
+
+// signCursor는 커서 payload의 HMAC-SHA256 서명을 계산합니다
+func (c *Client) signCursor(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.cursorKey)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorTamperDetection(t *testing.T) {
+	client := &Client{cursorKey: []byte("test-key")}
+	table := MustParseIdentifier("users")
+	scope := cursorScope(table, nil)
+
+	cursor, err := client.encodeCursor(cursorPayload{After: 42, Scope: scope})
+	require.NoError(t, err)
+
+	payload, err := client.decodeCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), payload.After)
+
+	// payload 변조
+	forged, err := client.encodeCursor(cursorPayload{After: 1000, Scope: scope})
+	require.NoError(t, err)
+	tampered := strings.SplitN(forged, ".", 2)[0] + "." + strings.SplitN(cursor, ".", 2)[1]
+	_, err = client.decodeCursor(tampered)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// 다른 키로 서명된 커서
+	other := &Client{cursorKey: []byte("other-key")}
+	_, err = other.decodeCursor(cursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// 형식 오류
+	_, err = client.decodeCursor("not-a-cursor")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// 패턴이 다르면 scope도 달라야 함
+	pattern := "John%"
+	assert.NotEqual(t, scope, cursorScope(table, &pattern))
+}
+
+func seedUsers(t *testing.T, client *Client, table Identifier, n int) {
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("User %03d", i)
+		if i%2 == 0 {
+			name = fmt.Sprintf("John %03d", i)
+		}
+		_, err := client.InsertUser(ctx, table, name, fmt.Sprintf("user%03d@example.com", i))
+		require.NoError(t, err)
+	}
+}
+
+func TestPostgreSQLGetUsersPage(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+	seedUsers(t, client, tableName, 25)
+
+	// 10개씩 페이지 순회
+	var ids []int
+	cursor := ""
+	pages := 0
+	for {
+		page, err := client.GetUsersPage(ctx, tableName, cursor, 10)
+		require.NoError(t, err)
+		pages++
+
+		for _, u := range page.Users {
+			ids = append(ids, u.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Len(t, ids, 25)
+	assert.IsIncreasing(t, ids)
+
+	// 패턴 검색 페이지네이션
+	page, err := client.GetUsersByNamePatternPage(ctx, tableName, "John%", "", 5)
+	require.NoError(t, err)
+	assert.Len(t, page.Users, 5)
+	assert.NotEmpty(t, page.NextCursor)
+
+	page, err = client.GetUsersByNamePatternPage(ctx, tableName, "John%", page.NextCursor, 10)
+	require.NoError(t, err)
+	assert.Len(t, page.Users, 8)
+	assert.Empty(t, page.NextCursor)
+
+	// 다른 조회에서 발급된 커서는 거부
+	first, err := client.GetUsersByNamePatternPage(ctx, tableName, "John%", "", 5)
+	require.NoError(t, err)
+	_, err = client.GetUsersPage(ctx, tableName, first.NextCursor, 5)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestPostgreSQLIterUsers(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+	seedUsers(t, client, tableName, 25)
+
+	// 작은 페이지 크기로 전체 순회
+	count := 0
+	lastID := 0
+	for user, err := range client.IterUsers(ctx, tableName, 4) {
+		require.NoError(t, err)
+		assert.Greater(t, user.ID, lastID)
+		lastID = user.ID
+		count++
+	}
+	assert.Equal(t, 25, count)
+
+	// 패턴 순회
+	count = 0
+	for user, err := range client.IterUsersByNamePattern(ctx, tableName, "John%", 3) {
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(user.Name, "John"))
+		count++
+	}
+	assert.Equal(t, 13, count)
+
+	// 중간에 중단
+	count = 0
+	for _, err := range client.IterUsers(ctx, tableName, 10) {
+		require.NoError(t, err)
+		count++
+		if count == 7 {
+			break
+		}
+	}
+	assert.Equal(t, 7, count)
+}
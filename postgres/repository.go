@@ -0,0 +1,309 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// column은 구조체 필드와 매핑되는 테이블 컬럼 정보를 나타냅니다
+type column struct {
+	name  string
+	index []int
+	pk    bool
+	auto  bool
+}
+
+// tableMeta는 db 태그에서 추출한 테이블 메타데이터와 SQL 문을 나타냅니다
+type tableMeta struct {
+	columns    []column
+	pk         column
+	selectSQL  string
+	insertSQL  string
+	updateSQL  string
+	deleteSQL  string
+	insertCols []column
+	updateCols []column
+	returnCols []column
+}
+
+// Repository는 db 태그가 지정된 구조체 T에 대한 CRUD 작업을 제공합니다
+//
+// 태그 형식은 `db:"컬럼명[,옵션...]"`이며 다음 옵션을 지원합니다
+//   - pk: 기본 키 컬럼 (정확히 하나 필요)
+//   - auto: DB가 값을 생성하는 컬럼 (INSERT/UPDATE에서 제외되고 RETURNING으로 채워짐)
+//
+// db 태그가 없거나 "-"인 필드는 무시됩니다
+type Repository[T any] struct {
+	client *Client
+	table  Identifier
+	meta   *tableMeta
+}
+
+// NewRepository는 T의 db 태그를 분석하여 새로운 Repository를 생성합니다
+func NewRepository[T any](client *Client, table Identifier) (*Repository[T], error) {
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+
+	meta, err := buildTableMeta(reflect.TypeFor[T](), table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository[T]{
+		client: client,
+		table:  table,
+		meta:   meta,
+	}, nil
+}
+
+// buildTableMeta는 구조체 타입에서 컬럼 목록과 SQL 문을 생성합니다
+func buildTableMeta(typ reflect.Type, table Identifier) (*tableMeta, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("repository type %s is not a struct", typ)
+	}
+
+	meta := &tableMeta{}
+	pkCount := 0
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("db")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		col := column{name: parts[0], index: field.Index}
+		if err := validateIdentifierPart(col.name); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "pk":
+				col.pk = true
+			case "auto":
+				col.auto = true
+			default:
+				return nil, fmt.Errorf("field %s has unknown db tag option %q", field.Name, opt)
+			}
+		}
+
+		if col.pk {
+			meta.pk = col
+			pkCount++
+		}
+		meta.columns = append(meta.columns, col)
+	}
+
+	if len(meta.columns) == 0 {
+		return nil, fmt.Errorf("repository type %s has no db tagged fields", typ)
+	}
+	if pkCount != 1 {
+		return nil, fmt.Errorf("repository type %s must have exactly one pk column, got %d", typ, pkCount)
+	}
+
+	var names []string
+	for _, col := range meta.columns {
+		names = append(names, quoteIdent(col.name))
+		if col.auto {
+			meta.returnCols = append(meta.returnCols, col)
+			continue
+		}
+		meta.insertCols = append(meta.insertCols, col)
+		if !col.pk {
+			meta.updateCols = append(meta.updateCols, col)
+		}
+	}
+
+	pk := quoteIdent(meta.pk.name)
+	meta.selectSQL = fmt.Sprintf("SELECT %s FROM %s", strings.Join(names, ", "), table)
+	meta.deleteSQL = fmt.Sprintf("DELETE FROM %s WHERE %s = $1", table, pk)
+	meta.insertSQL = buildInsertSQL(table, meta.insertCols, meta.returnCols)
+
+	if len(meta.updateCols) > 0 {
+		sets := make([]string, len(meta.updateCols))
+		for i, col := range meta.updateCols {
+			sets[i] = fmt.Sprintf("%s = $%d", quoteIdent(col.name), i+1)
+		}
+		meta.updateSQL = fmt.Sprintf(
+			"UPDATE %s SET %s WHERE %s = $%d",
+			table, strings.Join(sets, ", "), pk, len(meta.updateCols)+1,
+		)
+	}
+
+	return meta, nil
+}
+
+// buildInsertSQL은 INSERT 문을 생성합니다
+func buildInsertSQL(table Identifier, insertCols, returnCols []column) string {
+	var query string
+	if len(insertCols) == 0 {
+		query = fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", table)
+	} else {
+		names := make([]string, len(insertCols))
+		placeholders := make([]string, len(insertCols))
+		for i, col := range insertCols {
+			names[i] = quoteIdent(col.name)
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+		query = fmt.Sprintf(
+			"INSERT INTO %s (%s) VALUES (%s)",
+			table, strings.Join(names, ", "), strings.Join(placeholders, ", "),
+		)
+	}
+
+	if len(returnCols) > 0 {
+		names := make([]string, len(returnCols))
+		for i, col := range returnCols {
+			names[i] = quoteIdent(col.name)
+		}
+		query += " RETURNING " + strings.Join(names, ", ")
+	}
+	return query
+}
+
+// columnValues는 entity에서 지정한 컬럼들의 값을 추출합니다
+func columnValues(entity reflect.Value, cols []column) []interface{} {
+	args := make([]interface{}, len(cols))
+	for i, col := range cols {
+		args[i] = entity.FieldByIndex(col.index).Interface()
+	}
+	return args
+}
+
+// columnPointers는 entity에서 지정한 컬럼들의 필드 포인터를 추출합니다
+func columnPointers(entity reflect.Value, cols []column) []interface{} {
+	dest := make([]interface{}, len(cols))
+	for i, col := range cols {
+		dest[i] = entity.FieldByIndex(col.index).Addr().Interface()
+	}
+	return dest
+}
+
+// Table은 Repository가 사용하는 테이블 식별자를 반환합니다
+func (r *Repository[T]) Table() Identifier {
+	return r.table
+}
+
+// Insert는 entity를 추가하고 DB가 생성한 컬럼 값을 entity에 채웁니다
+func (r *Repository[T]) Insert(ctx context.Context, entity *T) error {
+	v := reflect.ValueOf(entity).Elem()
+	args := columnValues(v, r.meta.insertCols)
+
+	if len(r.meta.returnCols) == 0 {
+		_, err := r.client.conn(ctx).ExecContext(ctx, r.meta.insertSQL, args...)
+		return mapError(err)
+	}
+
+	err := r.client.conn(ctx).QueryRowContext(ctx, r.meta.insertSQL, args...).
+		Scan(columnPointers(v, r.meta.returnCols)...)
+	return mapError(err)
+}
+
+// Get은 기본 키로 entity를 조회합니다 (없으면 ErrNotFound)
+func (r *Repository[T]) Get(ctx context.Context, id interface{}) (*T, error) {
+	query := fmt.Sprintf("%s WHERE %s = $1", r.meta.selectSQL, quoteIdent(r.meta.pk.name))
+
+	var entity T
+	err := r.client.conn(ctx).GetContext(ctx, &entity, query, id)
+	if err == sql.ErrNoRows {
+		return nil, r.notFound(id)
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &entity, nil
+}
+
+// GetAll은 모든 entity를 기본 키 순서로 조회합니다
+func (r *Repository[T]) GetAll(ctx context.Context) ([]T, error) {
+	query := fmt.Sprintf("%s ORDER BY %s", r.meta.selectSQL, quoteIdent(r.meta.pk.name))
+
+	var entities []T
+	err := r.client.conn(ctx).SelectContext(ctx, &entities, query)
+	return entities, mapError(err)
+}
+
+// FindByPattern은 지정한 컬럼이 LIKE 패턴과 일치하는 entity를 조회합니다
+func (r *Repository[T]) FindByPattern(ctx context.Context, columnName, pattern string) ([]T, error) {
+	if !r.hasColumn(columnName) {
+		return nil, fmt.Errorf("unknown column %q for table %s", columnName, r.table)
+	}
+
+	query := fmt.Sprintf(
+		"%s WHERE %s LIKE $1 ORDER BY %s",
+		r.meta.selectSQL, quoteIdent(columnName), quoteIdent(r.meta.pk.name),
+	)
+
+	var entities []T
+	err := r.client.conn(ctx).SelectContext(ctx, &entities, query, pattern)
+	return entities, mapError(err)
+}
+
+// Update는 기본 키에 해당하는 행을 entity의 값으로 업데이트합니다
+func (r *Repository[T]) Update(ctx context.Context, entity *T) error {
+	if r.meta.updateSQL == "" {
+		return fmt.Errorf("table %s has no updatable columns", r.table)
+	}
+
+	v := reflect.ValueOf(entity).Elem()
+	id := v.FieldByIndex(r.meta.pk.index).Interface()
+	args := append(columnValues(v, r.meta.updateCols), id)
+
+	result, err := r.client.conn(ctx).ExecContext(ctx, r.meta.updateSQL, args...)
+	if err != nil {
+		return mapError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return mapError(err)
+	}
+
+	if rowsAffected == 0 {
+		return r.notFound(id)
+	}
+
+	return nil
+}
+
+// Delete는 기본 키에 해당하는 행을 삭제합니다
+func (r *Repository[T]) Delete(ctx context.Context, id interface{}) error {
+	result, err := r.client.conn(ctx).ExecContext(ctx, r.meta.deleteSQL, id)
+	if err != nil {
+		return mapError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return mapError(err)
+	}
+
+	if rowsAffected == 0 {
+		return r.notFound(id)
+	}
+
+	return nil
+}
+
+// notFound는 기본 키에 해당하는 행이 없다는 ErrNotFound 에러를 만듭니다
+func (r *Repository[T]) notFound(id interface{}) error {
+	return fmt.Errorf("row with %s %v in %s: %w", r.meta.pk.name, id, r.table, ErrNotFound)
+}
+
+// hasColumn은 컬럼 이름이 T에 매핑되어 있는지 확인합니다
+func (r *Repository[T]) hasColumn(name string) bool {
+	for _, col := range r.meta.columns {
+		if col.name == name {
+			return true
+		}
+	}
+	return false
+}
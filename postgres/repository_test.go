@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Order는 Repository 테스트용 주문 정보를 나타냅니다
+type Order struct {
+	ID       int64  `db:"order_id,pk,auto"`
+	Customer string `db:"customer"`
+	Amount   int    `db:"amount"`
+	Note     string `db:"-"`
+}
+
+func TestRepositoryMetadata(t *testing.T) {
+	repo, err := NewRepository[Order](nil, MustParseIdentifier("shop.orders"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `SELECT "order_id", "customer", "amount" FROM "shop"."orders"`, repo.meta.selectSQL)
+	assert.Equal(t, `INSERT INTO "shop"."orders" ("customer", "amount") VALUES ($1, $2) RETURNING "order_id"`, repo.meta.insertSQL)
+	assert.Equal(t, `UPDATE "shop"."orders" SET "customer" = $1, "amount" = $2 WHERE "order_id" = $3`, repo.meta.updateSQL)
+	assert.Equal(t, `DELETE FROM "shop"."orders" WHERE "order_id" = $1`, repo.meta.deleteSQL)
+
+	// 기본 키가 없는 구조체는 거부
+	type noPK struct {
+		Name string `db:"name"`
+	}
+	_, err = NewRepository[noPK](nil, MustParseIdentifier("no_pk"))
+	assert.Error(t, err)
+
+	// 알 수 없는 태그 옵션은 거부
+	type badOption struct {
+		ID int `db:"id,primary"`
+	}
+	_, err = NewRepository[badOption](nil, MustParseIdentifier("bad_option"))
+	assert.Error(t, err)
+
+	// 안전하지 않은 컬럼 이름은 거부
+	type badColumn struct {
+		ID int `db:"id;drop,pk"`
+	}
+	_, err = NewRepository[badColumn](nil, MustParseIdentifier("bad_column"))
+	assert.ErrorIs(t, err, ErrInvalidIdentifier)
+}
+
+func TestRepositoryUserCRUD(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+
+	repo, err := NewRepository[User](client, tableName)
+	require.NoError(t, err)
+
+	// 추가 - DB가 생성한 id와 created_at이 채워져야 함
+	user := &User{Name: "John Doe", Email: "john@example.com"}
+	err = repo.Insert(ctx, user)
+	require.NoError(t, err)
+	assert.Greater(t, user.ID, 0)
+	assert.NotEmpty(t, user.CreatedAt)
+
+	// 조회
+	found, err := repo.Get(ctx, user.ID)
+	assert.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "John Doe", found.Name)
+
+	// 업데이트
+	user.Name = "Jane Doe"
+	user.Email = "jane@example.com"
+	err = repo.Update(ctx, user)
+	assert.NoError(t, err)
+
+	found, err = client.GetUser(ctx, tableName, int64(user.ID))
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", found.Name)
+	assert.Equal(t, "jane@example.com", found.Email)
+
+	// 패턴 검색
+	results, err := repo.FindByPattern(ctx, "name", "Jane%")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	_, err = repo.FindByPattern(ctx, "name; DROP TABLE users", "%")
+	assert.Error(t, err)
+
+	// 삭제
+	err = repo.Delete(ctx, user.ID)
+	assert.NoError(t, err)
+
+	found, err = repo.Get(ctx, user.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, found)
+
+	// 없는 행 삭제
+	err = repo.Delete(ctx, user.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRepositoryCustomTable(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// orders 테이블은 마이그레이션으로 생성됨
+	repo, err := NewRepository[Order](client, MustParseIdentifier("orders"))
+	require.NoError(t, err)
+
+	orders := []*Order{
+		{Customer: "alice", Amount: 100},
+		{Customer: "bob", Amount: 200},
+		{Customer: "alice", Amount: 300},
+	}
+	for _, o := range orders {
+		err := repo.Insert(ctx, o)
+		require.NoError(t, err)
+		assert.Greater(t, o.ID, int64(0))
+	}
+
+	// 전체 조회
+	all, err := repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	// 특정 고객 주문 조회
+	aliceOrders, err := repo.FindByPattern(ctx, "customer", "alice")
+	assert.NoError(t, err)
+	assert.Len(t, aliceOrders, 2)
+
+	// 없는 행 업데이트
+	err = repo.Update(ctx, &Order{ID: 9999, Customer: "nobody"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
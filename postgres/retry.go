@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// 재시도 가능한 SQLSTATE 코드
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// RetryEvent는 트랜잭션 재시도 직전에 OnRetry 훅으로 전달되는 정보입니다
+type RetryEvent struct {
+	// Attempt는 실패한 시도 번호입니다 (1부터 시작)
+	Attempt int
+	Err     error
+	// Delay는 다음 시도까지 대기할 시간입니다
+	Delay time.Duration
+}
+
+// RetryPolicy는 트랜잭션 재시도 정책을 나타냅니다
+type RetryPolicy struct {
+	// MaxAttempts는 첫 시도를 포함한 최대 시도 횟수입니다
+	MaxAttempts int
+	// InitialBackoff는 첫 번째 재시도 전 최대 대기 시간입니다
+	InitialBackoff time.Duration
+	// MaxBackoff는 대기 시간의 상한입니다
+	MaxBackoff time.Duration
+	// Multiplier는 재시도마다 대기 시간에 곱하는 값입니다
+	Multiplier float64
+	// OnRetry는 재시도 직전에 호출됩니다 (nil 가능)
+	OnRetry func(RetryEvent)
+}
+
+// DefaultRetryPolicy는 기본 재시도 정책을 반환합니다
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+}
+
+// backoff는 attempt번째 실패 후 대기할 시간을 지터를 적용해 계산합니다
+//
+// 지수 증가한 값의 절반은 고정하고 나머지 절반은 무작위로 선택합니다
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= p.Multiplier
+		if delay >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	half := time.Duration(delay / 2)
+	if half <= 0 {
+		return 0
+	}
+	return half + rand.N(half+1)
+}
+
+// IsRetryable은 에러가 직렬화 실패(40001) 또는 교착 상태(40P01)인지 확인합니다
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == sqlStateSerializationFailure || pqErr.Code == sqlStateDeadlockDetected
+}
+
+// ExecuteInTransactionWithRetry는 재시도 가능한 에러가 발생하면 policy에 따라 트랜잭션 전체를 다시 실행합니다
+//
+// fn은 여러 번 호출될 수 있으므로 트랜잭션 밖에 부수 효과를 남기지 않아야 합니다
+// ctx에 이미 트랜잭션이 있으면 바깥 트랜잭션 전체가 실패한 상태이므로 재시도하지 않고 한 번만 실행합니다
+func (c *Client) ExecuteInTransactionWithRetry(ctx context.Context, opts *sql.TxOptions, policy RetryPolicy, fn TxFunc) error {
+	if c.currentTx(ctx) != nil {
+		return c.ExecuteInTransactionWithOptions(ctx, opts, fn)
+	}
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.ExecuteInTransactionWithOptions(ctx, opts, fn)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+		if attempt >= maxAttempts {
+			return fmt.Errorf("transaction failed after %d attempts: %w", attempt, err)
+		}
+
+		delay := policy.backoff(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(RetryEvent{Attempt: attempt, Err: err, Delay: delay})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&pq.Error{Code: "40001"}))
+	assert.True(t, IsRetryable(fmt.Errorf("commit: %w", &pq.Error{Code: "40P01"})))
+	assert.False(t, IsRetryable(&pq.Error{Code: "23505"}))
+	assert.False(t, IsRetryable(errors.New("serialization failure")))
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     400 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	// 지터 범위는 [delay/2, delay]
+	bounds := []time.Duration{100, 200, 400, 400, 400}
+	for i, upper := range bounds {
+		upper *= time.Millisecond
+		for j := 0; j < 20; j++ {
+			d := policy.backoff(i + 1)
+			assert.GreaterOrEqual(t, d, upper/2)
+			assert.LessOrEqual(t, d, upper)
+		}
+	}
+}
+
+func TestPostgreSQLRetryOnSerializationFailure(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, err := client.db.ExecContext(ctx, `
+		CREATE TABLE counters (
+			name TEXT PRIMARY KEY,
+			value INTEGER NOT NULL
+		);
+		INSERT INTO counters VALUES ('hits', 0);
+	`)
+	require.NoError(t, err)
+
+	const workers = 8
+	var retries atomic.Int32
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 20
+	policy.OnRetry = func(e RetryEvent) {
+		assert.True(t, IsRetryable(e.Err))
+		retries.Add(1)
+	}
+
+	// 모든 워커가 같은 값을 읽은 뒤 갱신하도록 첫 시도에서 대기
+	var readBarrier sync.WaitGroup
+	readBarrier.Add(workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			attempt := 0
+			serializable := &sql.TxOptions{Isolation: sql.LevelSerializable}
+			err := client.ExecuteInTransactionWithRetry(ctx, serializable, policy, func(ctx context.Context, tx *sqlx.Tx) error {
+				attempt++
+
+				var value int
+				if err := tx.GetContext(ctx, &value, "SELECT value FROM counters WHERE name = 'hits'"); err != nil {
+					return err
+				}
+				if attempt == 1 {
+					readBarrier.Done()
+					readBarrier.Wait()
+				}
+
+				_, err := tx.ExecContext(ctx, "UPDATE counters SET value = $1 WHERE name = 'hits'", value+1)
+				return err
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 충돌한 트랜잭션은 재시도되어 모든 증가가 반영되어야 함
+	var value int
+	err = client.db.GetContext(ctx, &value, "SELECT value FROM counters WHERE name = 'hits'")
+	require.NoError(t, err)
+	assert.Equal(t, workers, value)
+	assert.GreaterOrEqual(t, int(retries.Load()), workers-1)
+}
+
+func TestPostgreSQLRetryGivesUp(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// 재시도 불가능한 에러는 한 번만 실행
+	calls := 0
+	errPlain := errors.New("not retryable")
+	err := client.ExecuteInTransactionWithRetry(ctx, nil, DefaultRetryPolicy(), func(ctx context.Context, tx *sqlx.Tx) error {
+		calls++
+		return errPlain
+	})
+	assert.ErrorIs(t, err, errPlain)
+	assert.Equal(t, 1, calls)
+
+	// 최대 시도 횟수 초과
+	calls = 0
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 3
+	policy.InitialBackoff = time.Millisecond
+	err = client.ExecuteInTransactionWithRetry(ctx, nil, policy, func(ctx context.Context, tx *sqlx.Tx) error {
+		calls++
+		_, err := tx.ExecContext(ctx, "DO $$ BEGIN RAISE EXCEPTION 'forced' USING ERRCODE = '40001'; END $$")
+		return err
+	})
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrTxOptionsConflict는 중첩 트랜잭션이 바깥 트랜잭션과 호환되지 않는 옵션을 요청한 경우의 에러입니다
+var ErrTxOptionsConflict = errors.New("nested transaction options conflict with outer transaction")
+
+// TxFunc는 트랜잭션 내에서 실행되는 함수입니다
+//
+// ctx에는 현재 트랜잭션이 담겨 있으므로 이 ctx로 호출한 Client 메서드와
+// 중첩된 ExecuteInTransaction 호출은 같은 트랜잭션을 사용합니다
+type TxFunc func(ctx context.Context, tx *sqlx.Tx) error
+
+// txKey는 context에 트랜잭션 상태를 저장할 때 사용하는 키입니다
+type txKey struct{}
+
+// txState는 context로 전달되는 트랜잭션 상태입니다
+type txState struct {
+	client     *Client
+	tx         *sqlx.Tx
+	opts       sql.TxOptions
+	savepoints int
+	// afterCommit은 커밋 후 실행할 함수 목록입니다
+	afterCommit []func(ctx context.Context)
+}
+
+// queryer는 *sqlx.DB와 *sqlx.Tx가 공통으로 제공하는 쿼리 메서드입니다
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// TxFromContext는 ctx에 담긴 트랜잭션을 반환합니다
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// AfterCommit은 ctx의 트랜잭션이 커밋된 뒤 fn을 실행하도록 등록합니다
+//
+// ctx에 트랜잭션이 없으면 fn을 즉시 실행합니다. 트랜잭션이나 fn을 등록한 SAVEPOINT가
+// 롤백되면 fn은 실행되지 않습니다. 등록한 순서대로 트랜잭션 밖의 ctx로 실행됩니다
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// currentTx는 ctx에 담긴 이 Client의 트랜잭션 상태를 반환합니다
+func (c *Client) currentTx(ctx context.Context) *txState {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state.client != c {
+		return nil
+	}
+	return state
+}
+
+// conn은 ctx에 트랜잭션이 있으면 트랜잭션을, 없으면 DB 연결 풀을 반환합니다
+func (c *Client) conn(ctx context.Context) queryer {
+	if state := c.currentTx(ctx); state != nil {
+		return state.tx
+	}
+	return c.db
+}
+
+// ExecuteInTransactionWithOptions는 opts로 트랜잭션을 시작하고 fn을 실행합니다
+//
+// ctx에 이미 트랜잭션이 있으면 새 트랜잭션 대신 SAVEPOINT를 만들고,
+// fn이 에러를 반환하면 해당 SAVEPOINT까지만 롤백합니다
+func (c *Client) ExecuteInTransactionWithOptions(ctx context.Context, opts *sql.TxOptions, fn TxFunc) error {
+	if state := c.currentTx(ctx); state != nil {
+		if err := checkNestedOptions(state.opts, opts); err != nil {
+			return err
+		}
+		return c.executeInSavepoint(ctx, state, fn)
+	}
+
+	tx, err := c.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return mapError(err)
+	}
+
+	state := &txState{client: c, tx: tx}
+	if opts != nil {
+		state.opts = *opts
+	}
+	txCtx := context.WithValue(ctx, txKey{}, state)
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(txCtx, tx); err != nil {
+		tx.Rollback()
+		return mapError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return mapError(err)
+	}
+
+	for _, hook := range state.afterCommit {
+		hook(ctx)
+	}
+	return nil
+}
+
+// executeInSavepoint는 바깥 트랜잭션 안에서 SAVEPOINT를 만들고 fn을 실행합니다
+func (c *Client) executeInSavepoint(ctx context.Context, state *txState, fn TxFunc) error {
+	state.savepoints++
+	name := quoteIdent(fmt.Sprintf("sp_%d", state.savepoints))
+	hooks := len(state.afterCommit)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return mapError(err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			state.afterCommit = state.afterCommit[:hooks]
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx, state.tx); err != nil {
+		// 롤백된 SAVEPOINT 안에서 등록한 AfterCommit 함수는 버림
+		state.afterCommit = state.afterCommit[:hooks]
+		if _, rbErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(mapError(err), rbErr)
+		}
+		return mapError(err)
+	}
+
+	_, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return mapError(err)
+}
+
+// checkNestedOptions는 중첩 호출의 옵션이 바깥 트랜잭션에서 만족되는지 확인합니다
+func checkNestedOptions(outer sql.TxOptions, nested *sql.TxOptions) error {
+	if nested == nil {
+		return nil
+	}
+	if nested.Isolation != sql.LevelDefault && nested.Isolation != outer.Isolation {
+		return fmt.Errorf("%w: isolation %s requested inside %s", ErrTxOptionsConflict, nested.Isolation, outer.Isolation)
+	}
+	if nested.ReadOnly && !outer.ReadOnly {
+		return fmt.Errorf("%w: read-only requested inside read-write transaction", ErrTxOptionsConflict)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckNestedOptions(t *testing.T) {
+	serializable := sql.TxOptions{Isolation: sql.LevelSerializable}
+
+	assert.NoError(t, checkNestedOptions(serializable, nil))
+	assert.NoError(t, checkNestedOptions(serializable, &sql.TxOptions{}))
+	assert.NoError(t, checkNestedOptions(serializable, &sql.TxOptions{Isolation: sql.LevelSerializable}))
+	assert.NoError(t, checkNestedOptions(sql.TxOptions{ReadOnly: true}, &sql.TxOptions{ReadOnly: true}))
+
+	err := checkNestedOptions(serializable, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	assert.ErrorIs(t, err, ErrTxOptionsConflict)
+
+	err = checkNestedOptions(sql.TxOptions{}, &sql.TxOptions{ReadOnly: true})
+	assert.ErrorIs(t, err, ErrTxOptionsConflict)
+}
+
+func TestPostgreSQLNestedTransaction(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+	errInner := errors.New("inner failure")
+
+	// 재사용 가능한 트랜잭션 헬퍼
+	addUser := func(ctx context.Context, name, email string, fail bool) error {
+		return client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+			if _, err := client.InsertUser(ctx, tableName, name, email); err != nil {
+				return err
+			}
+			if fail {
+				return errInner
+			}
+			return nil
+		})
+	}
+
+	// 중첩 호출이 실패하면 해당 SAVEPOINT까지만 롤백
+	err := client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		_, ok := TxFromContext(ctx)
+		assert.True(t, ok)
+
+		if err := addUser(ctx, "John Doe", "john@example.com", false); err != nil {
+			return err
+		}
+
+		err := addUser(ctx, "Bob Johnson", "bob@example.com", true)
+		assert.ErrorIs(t, err, errInner)
+
+		// 트랜잭션은 계속 사용 가능해야 함
+		return addUser(ctx, "Jane Doe", "jane@example.com", false)
+	})
+	require.NoError(t, err)
+
+	users, err := client.GetAllUsers(ctx, tableName)
+	assert.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "John Doe", users[0].Name)
+	assert.Equal(t, "Jane Doe", users[1].Name)
+
+	// 바깥 트랜잭션이 실패하면 성공한 중첩 호출도 모두 롤백
+	errOuter := errors.New("outer failure")
+	err = client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := addUser(ctx, "Alice Kim", "alice@example.com", false); err != nil {
+			return err
+		}
+		return errOuter
+	})
+	assert.ErrorIs(t, err, errOuter)
+
+	users, err = client.GetAllUsers(ctx, tableName)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	// 중첩된 SAVEPOINT 안에서 제약 조건 위반이 발생해도 바깥 트랜잭션은 커밋 가능
+	err = client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		err := addUser(ctx, "Duplicate", "john@example.com", false)
+		assert.ErrorIs(t, err, ErrDuplicate)
+		return addUser(ctx, "Carol Lee", "carol@example.com", false)
+	})
+	assert.NoError(t, err)
+
+	users, err = client.GetAllUsers(ctx, tableName)
+	assert.NoError(t, err)
+	assert.Len(t, users, 3)
+}
+
+func TestPostgreSQLTransactionOptions(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableName := MustParseIdentifier("users")
+
+	// 읽기 전용 트랜잭션에서는 쓰기가 거부되어야 함
+	readOnly := &sql.TxOptions{ReadOnly: true}
+	err := client.ExecuteInTransactionWithOptions(ctx, readOnly, func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := client.InsertUser(ctx, tableName, "John Doe", "john@example.com")
+		return err
+	})
+	assert.Error(t, err)
+
+	// 격리 수준 적용 확인
+	serializable := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	err = client.ExecuteInTransactionWithOptions(ctx, serializable, func(ctx context.Context, tx *sqlx.Tx) error {
+		var level string
+		if err := tx.GetContext(ctx, &level, "SHOW transaction_isolation"); err != nil {
+			return err
+		}
+		assert.Equal(t, "serializable", level)
+
+		// 호환되지 않는 옵션으로 중첩 호출
+		err := client.ExecuteInTransactionWithOptions(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted},
+			func(ctx context.Context, tx *sqlx.Tx) error { return nil })
+		assert.ErrorIs(t, err, ErrTxOptionsConflict)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestPostgreSQLAfterCommit(t *testing.T) {
+	client, cleanup := setupPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	errFail := errors.New("failure")
+
+	// 트랜잭션이 없으면 즉시 실행
+	var events []string
+	AfterCommit(ctx, func(ctx context.Context) { events = append(events, "immediate") })
+	assert.Equal(t, []string{"immediate"}, events)
+
+	// 커밋 후 등록 순서대로 실행, 롤백된 SAVEPOINT에서 등록한 함수는 제외
+	events = nil
+	err := client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		AfterCommit(ctx, func(ctx context.Context) { events = append(events, "first") })
+
+		err := client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+			AfterCommit(ctx, func(ctx context.Context) { events = append(events, "rolled back") })
+			return errFail
+		})
+		assert.ErrorIs(t, err, errFail)
+
+		err = client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+			AfterCommit(ctx, func(ctx context.Context) { events = append(events, "nested") })
+			return nil
+		})
+		require.NoError(t, err)
+
+		// 커밋 전에는 실행되지 않음
+		assert.Empty(t, events)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "nested"}, events)
+
+	// 트랜잭션이 롤백되면 실행하지 않음
+	events = nil
+	err = client.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		AfterCommit(ctx, func(ctx context.Context) { events = append(events, "never") })
+		return errFail
+	})
+	assert.ErrorIs(t, err, errFail)
+	assert.Empty(t, events)
+}
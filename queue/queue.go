@@ -0,0 +1,553 @@
+// Package queue는 Redis 리스트로 구현한 신뢰성 있는 작업 큐를 제공합니다
+//
+// 컨슈머는 BLMOVE로 대기 리스트의 작업을 자신의 처리 중 리스트로 옮긴 뒤 처리하고,
+// 끝나면 ACK로 제거합니다. 가시성 타임아웃 안에 ACK나 연장이 없는 작업은 리퍼가
+// 대기 리스트로 되돌리고, 실패한 작업은 지수 백오프로 예약 ZSET에 넣었다가 다시 실행합니다.
+// 최대 시도 횟수를 넘은 작업은 데드 레터 리스트로 옮깁니다
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"testcontainers-learning/redis"
+)
+
+// 기본 설정값
+const (
+	DefaultPrefix            = "queue"
+	DefaultVisibilityTimeout = 30 * time.Second
+	DefaultMaxAttempts       = 5
+	DefaultPollInterval      = time.Second
+)
+
+// promoteBatch는 한 번에 대기 리스트로 옮길 최대 예약 작업 수입니다
+const promoteBatch = 100
+
+// errorBackoff는 작업을 가져오지 못했을 때 다시 시도하기 전 대기 시간입니다
+const errorBackoff = 500 * time.Millisecond
+
+var (
+	// ErrNoJob은 timeout 동안 가져올 작업이 없을 때 반환됩니다
+	ErrNoJob = errors.New("no job available")
+	// ErrLeaseLost는 가시성 타임아웃이 지나 리퍼가 작업을 회수한 뒤 ACK나 연장을 시도했을 때 반환됩니다
+	ErrLeaseLost = errors.New("job lease lost")
+	// ErrVisibilityTimeout은 리퍼가 회수한 작업의 LastError에 기록되는 사유입니다
+	ErrVisibilityTimeout = errors.New("visibility timeout expired")
+)
+
+// Job은 큐에 저장되는 작업입니다
+type Job struct {
+	ID      string `json:"id"`
+	Payload []byte `json:"payload"`
+	// Attempts는 지금까지 실패하거나 회수된 횟수입니다 (처음 전달될 때 0)
+	Attempts   int       `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	// LastError는 마지막 실패 사유입니다
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Handler는 작업 하나를 처리합니다
+//
+// nil을 반환하면 작업이 ACK되고, 에러를 반환하면 백오프 후 다시 시도됩니다
+type Handler func(ctx context.Context, job Job) error
+
+// Stats는 큐 상태별 작업 수입니다
+type Stats struct {
+	Ready      int64
+	Delayed    int64
+	Processing int64
+	Dead       int64
+}
+
+// config는 Queue 설정입니다
+type config struct {
+	prefix       string
+	visibility   time.Duration
+	maxAttempts  int
+	retryInitial time.Duration
+	retryMax     time.Duration
+	pollInterval time.Duration
+	onError      func(error)
+}
+
+// Option은 Queue 동작을 설정합니다
+type Option func(*config)
+
+// WithPrefix는 Redis 키 접두사를 설정합니다 (기본값 DefaultPrefix)
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// WithVisibilityTimeout은 ACK나 연장 없이 작업을 붙잡고 있을 수 있는 시간을 설정합니다
+//
+// 기본값은 DefaultVisibilityTimeout이며, Work는 처리 중인 작업을 타임아웃의 1/3마다 연장합니다
+func WithVisibilityTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.visibility = d
+	}
+}
+
+// WithMaxAttempts는 데드 레터로 옮기기 전까지 최대 시도 횟수를 설정합니다 (기본값 DefaultMaxAttempts)
+func WithMaxAttempts(n int) Option {
+	return func(c *config) {
+		c.maxAttempts = n
+	}
+}
+
+// WithRetryBackoff는 실패한 작업을 다시 실행하기 전 대기 시간을 설정합니다
+//
+// 대기 시간은 initial부터 실패할 때마다 두 배씩 늘어나 max를 넘지 않습니다 (기본값 1초, 1분)
+func WithRetryBackoff(initial, max time.Duration) Option {
+	return func(c *config) {
+		c.retryInitial = initial
+		c.retryMax = max
+	}
+}
+
+// WithPollInterval은 Work가 작업을 기다리는 최대 시간을 설정합니다 (기본값 DefaultPollInterval)
+//
+// 이 주기마다 실행 시각이 된 예약 작업을 대기 리스트로 옮깁니다
+func WithPollInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.pollInterval = d
+	}
+}
+
+// WithErrorHandler는 Work와 RunReaper에서 호출자에게 반환할 수 없는 에러를 받을 함수를 설정합니다
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Queue는 Redis 리스트 기반 작업 큐입니다
+type Queue struct {
+	client *redis.Client
+	name   string
+	cfg    config
+
+	readyKey   string
+	delayedKey string
+	deadKey    string
+	leasesKey  string
+}
+
+// New는 name 큐를 생성합니다
+//
+// 키에는 "{name}" 해시 태그가 붙으므로 Cluster에서도 여러 키를 옮기는 스크립트가 같은 슬롯에서 실행됩니다
+func New(client *redis.Client, name string, opts ...Option) *Queue {
+	cfg := config{
+		prefix:       DefaultPrefix,
+		visibility:   DefaultVisibilityTimeout,
+		maxAttempts:  DefaultMaxAttempts,
+		retryInitial: time.Second,
+		retryMax:     time.Minute,
+		pollInterval: DefaultPollInterval,
+		onError:      func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	base := cfg.prefix + ":{" + name + "}"
+	return &Queue{
+		client:     client,
+		name:       name,
+		cfg:        cfg,
+		readyKey:   base + ":ready",
+		delayedKey: base + ":delayed",
+		deadKey:    base + ":dead",
+		leasesKey:  base + ":leases",
+	}
+}
+
+// Name은 큐 이름을 반환합니다
+func (q *Queue) Name() string {
+	return q.name
+}
+
+// processingKey는 consumer의 처리 중 리스트 키를 만듭니다
+func (q *Queue) processingKey(consumer string) string {
+	return q.cfg.prefix + ":{" + q.name + "}:processing:" + consumer
+}
+
+// Enqueue는 작업을 대기 리스트 맨 뒤에 추가하고 작업 ID를 반환합니다
+func (q *Queue) Enqueue(ctx context.Context, payload []byte) (string, error) {
+	job, raw, err := newJob(payload)
+	if err != nil {
+		return "", err
+	}
+	if err := q.client.LPush(ctx, q.readyKey, raw); err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+// EnqueueIn은 작업을 delay 뒤에 실행되도록 예약하고 작업 ID를 반환합니다
+func (q *Queue) EnqueueIn(ctx context.Context, payload []byte, delay time.Duration) (string, error) {
+	if delay <= 0 {
+		return q.Enqueue(ctx, payload)
+	}
+
+	job, raw, err := newJob(payload)
+	if err != nil {
+		return "", err
+	}
+	if _, err := q.client.RunScript(ctx, scheduleScript, []string{q.delayedKey}, delay.Milliseconds(), raw); err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+// EnqueueAt은 작업을 at에 실행되도록 예약하고 작업 ID를 반환합니다
+func (q *Queue) EnqueueAt(ctx context.Context, payload []byte, at time.Time) (string, error) {
+	return q.EnqueueIn(ctx, payload, time.Until(at))
+}
+
+// newJob은 새 작업과 저장할 문자열을 만듭니다
+func newJob(payload []byte) (Job, string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Job{}, "", err
+	}
+	job := Job{ID: hex.EncodeToString(b), Payload: payload, EnqueuedAt: time.Now()}
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return Job{}, "", err
+	}
+	return job, string(raw), nil
+}
+
+// Fetch는 작업 하나를 consumer의 처리 중 리스트로 옮겨 반환합니다
+//
+// 실행 시각이 된 예약 작업을 먼저 대기 리스트로 옮기고, 작업이 없으면 최대 timeout 동안
+// 기다린 뒤 ErrNoJob을 반환합니다 (timeout이 0이면 작업이 들어올 때까지 기다림).
+// 반환된 작업은 가시성 타임아웃 안에 Ack, Nack 또는 Extend해야 합니다
+func (q *Queue) Fetch(ctx context.Context, consumer string, timeout time.Duration) (*Delivery, error) {
+	if _, err := q.promote(ctx); err != nil {
+		return nil, err
+	}
+
+	processing := q.processingKey(consumer)
+	raw, err := q.client.BLMove(ctx, q.readyKey, processing, "RIGHT", "LEFT", timeout)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, ErrNoJob
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Delivery{queue: q, processing: processing, raw: raw}
+	if err := json.Unmarshal([]byte(raw), &d.Job); err != nil {
+		// 해석할 수 없는 작업은 다시 시도해도 소용없으므로 데드 레터로 옮김
+		_, moveErr := q.client.RunScript(ctx, moveScript, []string{processing, q.leasesKey, q.deadKey}, raw, raw, "back", 0)
+		return nil, errors.Join(fmt.Errorf("queue: invalid job %q: %w", raw, err), moveErr)
+	}
+
+	// 여기서 실패해도 리퍼가 처음 발견한 시점부터 타임아웃을 적용함
+	if _, err := q.client.RunScript(ctx, leaseScript, []string{q.leasesKey}, raw, q.cfg.visibility.Milliseconds()); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// promote는 실행 시각이 된 예약 작업을 대기 리스트로 옮기고 옮긴 수를 반환합니다
+func (q *Queue) promote(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		reply, err := q.client.RunScript(ctx, promoteScript, []string{q.delayedKey, q.readyKey}, promoteBatch)
+		if err != nil {
+			return total, err
+		}
+		n, _ := reply.(int64)
+		total += int(n)
+		if n < promoteBatch {
+			return total, nil
+		}
+	}
+}
+
+// Reap은 가시성 타임아웃이 지난 작업을 대기 리스트 맨 앞으로 되돌리고 되돌린 수를 반환합니다
+//
+// 실행 시각이 된 예약 작업도 함께 대기 리스트로 옮깁니다. 회수된 작업은 한 번 실패한 것으로
+// 세며, 최대 시도 횟수를 넘으면 데드 레터 리스트로 옮깁니다
+func (q *Queue) Reap(ctx context.Context) (int, error) {
+	if _, err := q.promote(ctx); err != nil {
+		return 0, err
+	}
+
+	reaped := 0
+	for key, err := range q.client.Scan(ctx, q.processingKey("*"), 100) {
+		if err != nil {
+			return reaped, err
+		}
+
+		reply, err := q.client.RunScript(ctx, expiredScript, []string{key, q.leasesKey}, q.cfg.visibility.Milliseconds())
+		if err != nil {
+			return reaped, err
+		}
+		expired, _ := reply.([]interface{})
+		for _, v := range expired {
+			raw, _ := v.(string)
+			moved, err := q.retry(ctx, key, raw, ErrVisibilityTimeout, true)
+			if err != nil {
+				return reaped, err
+			}
+			if moved {
+				reaped++
+			}
+		}
+	}
+	return reaped, nil
+}
+
+// retry는 처리 중인 작업의 시도 횟수를 늘려 다시 실행하거나 데드 레터로 옮깁니다
+//
+// front이면 대기 리스트 맨 앞으로 바로 되돌리고, 아니면 백오프만큼 뒤로 예약합니다.
+// 작업이 이미 처리 중 리스트에 없으면 false를 반환합니다
+func (q *Queue) retry(ctx context.Context, processing, raw string, cause error, front bool) (bool, error) {
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		reply, moveErr := q.client.RunScript(ctx, moveScript, []string{processing, q.leasesKey, q.deadKey}, raw, raw, "back", 0)
+		return reply == int64(1), errors.Join(fmt.Errorf("queue: invalid job %q: %w", raw, err), moveErr)
+	}
+
+	job.Attempts++
+	job.LastError = cause.Error()
+	updated, err := json.Marshal(job)
+	if err != nil {
+		return false, err
+	}
+
+	target, mode, delay := q.readyKey, "front", time.Duration(0)
+	switch {
+	case job.Attempts >= q.cfg.maxAttempts:
+		target, mode = q.deadKey, "back"
+	case !front:
+		delay = q.backoff(job.Attempts)
+		if delay > 0 {
+			target, mode = q.delayedKey, "delayed"
+		} else {
+			mode = "back"
+		}
+	}
+
+	reply, err := q.client.RunScript(ctx, moveScript, []string{processing, q.leasesKey, target}, raw, string(updated), mode, delay.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	return reply == int64(1), nil
+}
+
+// backoff는 attempts번 실패한 작업을 다시 실행하기 전 대기 시간을 계산합니다
+func (q *Queue) backoff(attempts int) time.Duration {
+	delay := q.cfg.retryInitial
+	for i := 1; i < attempts && delay < q.cfg.retryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, q.cfg.retryMax)
+}
+
+// Stats는 상태별 작업 수를 반환합니다
+func (q *Queue) Stats(ctx context.Context) (Stats, error) {
+	reply, err := q.client.RunScript(ctx, statsScript, []string{q.readyKey, q.delayedKey, q.deadKey})
+	if err != nil {
+		return Stats{}, err
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 3 {
+		return Stats{}, fmt.Errorf("unexpected queue stats reply %v", reply)
+	}
+
+	var stats Stats
+	stats.Ready, _ = values[0].(int64)
+	stats.Delayed, _ = values[1].(int64)
+	stats.Dead, _ = values[2].(int64)
+
+	for key, err := range q.client.Scan(ctx, q.processingKey("*"), 100) {
+		if err != nil {
+			return stats, err
+		}
+		n, err := q.client.LLen(ctx, key)
+		if err != nil {
+			return stats, err
+		}
+		stats.Processing += n
+	}
+	return stats, nil
+}
+
+// Dead는 데드 레터 리스트의 작업을 오래된 순서로 반환합니다
+func (q *Queue) Dead(ctx context.Context) ([]Job, error) {
+	items, err := q.client.LRange(ctx, q.deadKey, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		var job Job
+		if err := json.Unmarshal([]byte(items[i]), &job); err != nil {
+			job = Job{Payload: []byte(items[i]), LastError: err.Error()}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Work는 ctx가 취소될 때까지 consumer 이름으로 작업을 하나씩 가져와 handler로 처리합니다
+//
+// 처리 중인 작업은 가시성 타임아웃의 1/3마다 연장됩니다. ctx가 취소되면 처리 중인 작업을
+// 마친 뒤 nil을 반환하며, 핸들러에는 취소되지 않는 ctx가 전달됩니다.
+// 동시에 처리하려면 서로 다른 consumer 이름으로 Work를 여러 개 실행합니다
+func (q *Queue) Work(ctx context.Context, consumer string, handler Handler) error {
+	handlerCtx := context.WithoutCancel(ctx)
+	for {
+		d, err := q.Fetch(ctx, consumer, q.cfg.pollInterval)
+		if ctx.Err() != nil {
+			if d != nil {
+				// 꺼낸 직후 취소된 작업도 끝까지 처리
+				q.process(handlerCtx, d, handler)
+			}
+			return nil
+		}
+		if errors.Is(err, ErrNoJob) {
+			continue
+		}
+		if err != nil {
+			q.cfg.onError(fmt.Errorf("queue: fetch %s: %w", q.name, err))
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(errorBackoff):
+			}
+			continue
+		}
+
+		q.process(handlerCtx, d, handler)
+	}
+}
+
+// process는 가시성 타임아웃을 연장하면서 handler를 실행하고 결과에 따라 ACK 또는 NACK합니다
+func (q *Queue) process(ctx context.Context, d *Delivery, handler Handler) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(max(q.cfg.visibility/3, time.Millisecond))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := d.Extend(ctx); err != nil {
+					q.cfg.onError(fmt.Errorf("queue: extend %s: %w", d.Job.ID, err))
+					if errors.Is(err, ErrLeaseLost) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	err := handle(ctx, d.Job, handler)
+	close(done)
+	<-stopped
+
+	if err == nil {
+		err = d.Ack(ctx)
+	} else {
+		q.cfg.onError(fmt.Errorf("queue: handle %s (attempt %d): %w", d.Job.ID, d.Job.Attempts+1, err))
+		err = d.Nack(ctx, err)
+	}
+	if err != nil {
+		q.cfg.onError(fmt.Errorf("queue: settle %s: %w", d.Job.ID, err))
+	}
+}
+
+// handle은 handler를 실행하고 panic을 에러로 바꿉니다
+func handle(ctx context.Context, job Job, handler Handler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panic: %v", p)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// RunReaper는 ctx가 취소될 때까지 interval마다 Reap을 실행합니다
+func (q *Queue) RunReaper(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if _, err := q.Reap(ctx); err != nil && ctx.Err() == nil {
+			q.cfg.onError(fmt.Errorf("queue: reap %s: %w", q.name, err))
+		}
+	}
+}
+
+// Delivery는 컨슈머가 가져온 처리 중인 작업입니다
+type Delivery struct {
+	Job Job
+
+	queue      *Queue
+	processing string
+	raw        string
+}
+
+// Ack는 작업 처리를 완료하고 큐에서 제거합니다
+func (d *Delivery) Ack(ctx context.Context) error {
+	reply, err := d.queue.client.RunScript(ctx, ackScript, []string{d.processing, d.queue.leasesKey}, d.raw)
+	if err != nil {
+		return err
+	}
+	if reply != int64(1) {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Nack는 작업 처리 실패를 기록하고 백오프 뒤에 다시 실행되도록 합니다
+//
+// 최대 시도 횟수에 도달하면 데드 레터 리스트로 옮깁니다
+func (d *Delivery) Nack(ctx context.Context, cause error) error {
+	if cause == nil {
+		cause = errors.New("nack")
+	}
+	moved, err := d.queue.retry(ctx, d.processing, d.raw, cause, false)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Extend는 가시성 타임아웃을 지금부터 다시 적용합니다
+func (d *Delivery) Extend(ctx context.Context) error {
+	reply, err := d.queue.client.RunScript(ctx, extendScript, []string{d.queue.leasesKey}, d.raw, d.queue.cfg.visibility.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if reply != int64(1) {
+		return ErrLeaseLost
+	}
+	return nil
+}
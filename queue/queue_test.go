@@ -0,0 +1,345 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"testcontainers-learning/redis"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Redis 컨테이너 시작 (모든 테스트에서 공유)
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	if err != nil {
+		panic(err)
+	}
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		_ = testcontainers.TerminateContainer(redisContainer)
+		panic(err)
+	}
+	testClient = redis.NewClient(endpoint)
+
+	// 테스트 실행
+	code := m.Run()
+
+	// 정리
+	testClient.Close()
+	if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+		panic(err)
+	}
+
+	os.Exit(code)
+}
+
+// newQueue는 테스트마다 다른 접두사를 쓰는 Queue를 만듭니다
+func newQueue(t *testing.T, opts ...Option) *Queue {
+	prefix := "test:" + t.Name()
+	ctx := context.Background()
+	for key, err := range testClient.Scan(ctx, prefix+":*", 100) {
+		require.NoError(t, err)
+		require.NoError(t, testClient.Delete(ctx, key))
+	}
+
+	opts = append([]Option{WithPrefix(prefix)}, opts...)
+	return New(testClient, "jobs", opts...)
+}
+
+func TestQueueKeysShareSlot(t *testing.T) {
+	q := New(testClient, "jobs")
+
+	// 스크립트가 함께 다루는 키는 모두 같은 해시 태그를 가짐
+	for _, key := range []string{q.readyKey, q.delayedKey, q.deadKey, q.leasesKey, q.processingKey("worker")} {
+		assert.Contains(t, key, DefaultPrefix+":{jobs}:")
+	}
+}
+
+func TestQueueFetchAck(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t)
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, err := q.Enqueue(ctx, []byte(fmt.Sprintf("job-%d", i)))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// 넣은 순서대로 꺼내고, 꺼낸 작업은 처리 중으로 이동
+	first, err := q.Fetch(ctx, "worker-1", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], first.Job.ID)
+	assert.Equal(t, []byte("job-0"), first.Job.Payload)
+	assert.Zero(t, first.Job.Attempts)
+
+	second, err := q.Fetch(ctx, "worker-2", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], second.Job.ID)
+
+	stats, err := q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Ready: 1, Processing: 2}, stats)
+
+	require.NoError(t, first.Ack(ctx))
+	require.NoError(t, second.Ack(ctx))
+	assert.ErrorIs(t, first.Ack(ctx), ErrLeaseLost)
+
+	stats, err = q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Ready: 1}, stats)
+}
+
+func TestQueueFetchTimeout(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t)
+
+	start := time.Now()
+	_, err := q.Fetch(ctx, "worker", 100*time.Millisecond)
+	assert.ErrorIs(t, err, ErrNoJob)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// 기다리는 중에 들어온 작업을 바로 가져옴
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = q.Enqueue(ctx, []byte("late"))
+	}()
+	d, err := q.Fetch(ctx, "worker", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("late"), d.Job.Payload)
+}
+
+func TestQueueDelayedJobs(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t)
+
+	_, err := q.EnqueueIn(ctx, []byte("later"), 200*time.Millisecond)
+	require.NoError(t, err)
+	_, err = q.EnqueueAt(ctx, []byte("now"), time.Now().Add(-time.Second))
+	require.NoError(t, err)
+
+	stats, err := q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Ready: 1, Delayed: 1}, stats)
+
+	d, err := q.Fetch(ctx, "worker", 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("now"), d.Job.Payload)
+	require.NoError(t, d.Ack(ctx))
+
+	// 실행 시각 전에는 가져올 수 없음
+	_, err = q.Fetch(ctx, "worker", 50*time.Millisecond)
+	assert.ErrorIs(t, err, ErrNoJob)
+
+	time.Sleep(200 * time.Millisecond)
+	d, err = q.Fetch(ctx, "worker", 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("later"), d.Job.Payload)
+}
+
+func TestQueueNackRetriesAndDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t, WithMaxAttempts(2), WithRetryBackoff(100*time.Millisecond, time.Second))
+
+	id, err := q.Enqueue(ctx, []byte("flaky"))
+	require.NoError(t, err)
+
+	d, err := q.Fetch(ctx, "worker", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, d.Nack(ctx, errors.New("temporary failure")))
+	assert.ErrorIs(t, d.Nack(ctx, errors.New("again")), ErrLeaseLost)
+
+	// 백오프 동안은 예약 상태
+	stats, err := q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Delayed: 1}, stats)
+
+	_, err = q.Fetch(ctx, "worker", 20*time.Millisecond)
+	assert.ErrorIs(t, err, ErrNoJob)
+
+	time.Sleep(100 * time.Millisecond)
+	d, err = q.Fetch(ctx, "worker", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, id, d.Job.ID)
+	assert.Equal(t, 1, d.Job.Attempts)
+	assert.Equal(t, "temporary failure", d.Job.LastError)
+
+	// 최대 시도 횟수에 도달하면 데드 레터로 이동
+	require.NoError(t, d.Nack(ctx, errors.New("permanent failure")))
+
+	stats, err = q.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Dead: 1}, stats)
+
+	dead, err := q.Dead(ctx)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, id, dead[0].ID)
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Equal(t, "permanent failure", dead[0].LastError)
+}
+
+func TestQueueReaper(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t, WithVisibilityTimeout(100*time.Millisecond))
+
+	id, err := q.Enqueue(ctx, []byte("stuck"))
+	require.NoError(t, err)
+
+	// 작업을 가져간 뒤 죽은 컨슈머
+	crashed, err := q.Fetch(ctx, "crashed", time.Second)
+	require.NoError(t, err)
+
+	// 타임아웃 전에는 회수하지 않음
+	n, err := q.Reap(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	time.Sleep(150 * time.Millisecond)
+	n, err = q.Reap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// 회수된 작업은 다른 컨슈머가 가져가고, 원래 컨슈머의 ACK는 실패
+	d, err := q.Fetch(ctx, "survivor", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, id, d.Job.ID)
+	assert.Equal(t, 1, d.Job.Attempts)
+	assert.Equal(t, ErrVisibilityTimeout.Error(), d.Job.LastError)
+
+	assert.ErrorIs(t, crashed.Ack(ctx), ErrLeaseLost)
+	assert.ErrorIs(t, crashed.Extend(ctx), ErrLeaseLost)
+	require.NoError(t, d.Ack(ctx))
+}
+
+func TestQueueReaperOrphanedJob(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t, WithVisibilityTimeout(100*time.Millisecond))
+
+	// BLMOVE 직후 임대 기록 전에 죽은 컨슈머
+	_, err := q.Enqueue(ctx, []byte("orphan"))
+	require.NoError(t, err)
+	_, err = testClient.LMove(ctx, q.readyKey, q.processingKey("crashed"), "RIGHT", "LEFT")
+	require.NoError(t, err)
+
+	// 처음 발견한 시점부터 타임아웃 적용
+	n, err := q.Reap(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	time.Sleep(150 * time.Millisecond)
+	n, err = q.Reap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestQueueExtend(t *testing.T) {
+	ctx := context.Background()
+	q := newQueue(t, WithVisibilityTimeout(100*time.Millisecond))
+
+	_, err := q.Enqueue(ctx, []byte("long"))
+	require.NoError(t, err)
+	d, err := q.Fetch(ctx, "worker", time.Second)
+	require.NoError(t, err)
+
+	// 연장하는 동안은 회수되지 않음
+	for i := 0; i < 4; i++ {
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, d.Extend(ctx))
+		n, err := q.Reap(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	}
+	require.NoError(t, d.Ack(ctx))
+}
+
+func TestQueueWork(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var reported atomic.Int32
+	q := newQueue(t,
+		WithVisibilityTimeout(150*time.Millisecond),
+		WithRetryBackoff(10*time.Millisecond, 50*time.Millisecond),
+		WithPollInterval(20*time.Millisecond),
+		WithErrorHandler(func(err error) {
+			if strings.Contains(err.Error(), "try again") {
+				reported.Add(1)
+			}
+		}),
+	)
+
+	var mu sync.Mutex
+	handled := make(map[string]int)
+	handler := func(ctx context.Context, job Job) error {
+		mu.Lock()
+		handled[string(job.Payload)]++
+		count := handled[string(job.Payload)]
+		mu.Unlock()
+
+		switch string(job.Payload) {
+		case "fail-once":
+			if count == 1 {
+				return errors.New("try again")
+			}
+		case "slow":
+			// 가시성 타임아웃보다 오래 걸려도 연장되므로 회수되지 않음
+			time.Sleep(400 * time.Millisecond)
+		}
+		return nil
+	}
+
+	payloads := []string{"a", "b", "c", "fail-once", "slow"}
+	for _, p := range payloads {
+		_, err := q.Enqueue(ctx, []byte(p))
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, q.Work(ctx, fmt.Sprintf("worker-%d", i), handler))
+		}()
+	}
+
+	reaperDone := make(chan struct{})
+	go func() {
+		defer close(reaperDone)
+		assert.NoError(t, q.RunReaper(ctx, 20*time.Millisecond))
+	}()
+
+	assert.Eventually(t, func() bool {
+		stats, err := q.Stats(ctx)
+		return err == nil && stats == Stats{}
+	}, 5*time.Second, 20*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+	<-reaperDone
+
+	// 핸들러 실패는 에러 핸들러로 전달됨
+	assert.Equal(t, int32(1), reported.Load())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1, "fail-once": 2, "slow": 1}, handled)
+}
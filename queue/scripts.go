@@ -0,0 +1,109 @@
+package queue
+
+import "testcontainers-learning/redis"
+
+// 모든 시각은 서버 TIME 기준 밀리초입니다
+
+// scheduleScript는 작업을 delay 뒤에 실행되도록 예약합니다
+//
+// KEYS[1]: 예약 ZSET, ARGV: delay(ms), 작업
+var scheduleScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+return redis.call('ZADD', KEYS[1], now + tonumber(ARGV[1]), ARGV[2])
+`)
+
+// promoteScript는 실행 시각이 된 예약 작업을 최대 limit개 대기 리스트로 옮깁니다
+//
+// KEYS[1]: 예약 ZSET, KEYS[2]: 대기 리스트, ARGV: limit
+var promoteScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now, 'LIMIT', 0, tonumber(ARGV[1]))
+for _, job in ipairs(due) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('LPUSH', KEYS[2], job)
+end
+return #due
+`)
+
+// leaseScript는 작업의 가시성 타임아웃 만료 시각을 기록합니다
+//
+// KEYS[1]: 임대 해시, ARGV: 작업, 가시성 타임아웃(ms)
+var leaseScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+return redis.call('HSET', KEYS[1], ARGV[1], now + tonumber(ARGV[2]))
+`)
+
+// extendScript는 아직 회수되지 않은 작업의 만료 시각을 연장합니다 (회수되었으면 0)
+//
+// KEYS[1]: 임대 해시, ARGV: 작업, 가시성 타임아웃(ms)
+var extendScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+redis.call('HSET', KEYS[1], ARGV[1], now + tonumber(ARGV[2]))
+return 1
+`)
+
+// ackScript는 처리 중 리스트에서 작업을 제거합니다 (이미 회수되었으면 0)
+//
+// KEYS[1]: 처리 중 리스트, KEYS[2]: 임대 해시, ARGV: 작업
+var ackScript = redis.NewScript(`
+local removed = redis.call('LREM', KEYS[1], 1, ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+return removed
+`)
+
+// moveScript는 처리 중인 작업을 제거하고 갱신된 작업을 다른 곳으로 옮깁니다 (이미 회수되었으면 0)
+//
+// mode가 "delayed"이면 delay 뒤로 예약하고, "front"이면 대기 리스트의 맨 앞에,
+// "back"이면 맨 뒤(데드 레터 리스트 포함)에 넣습니다
+//
+// KEYS[1]: 처리 중 리스트, KEYS[2]: 임대 해시, KEYS[3]: 대상, ARGV: 작업, 갱신된 작업, mode, delay(ms)
+var moveScript = redis.NewScript(`
+if redis.call('LREM', KEYS[1], 1, ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HDEL', KEYS[2], ARGV[1])
+if ARGV[3] == 'delayed' then
+	local t = redis.call('TIME')
+	local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+	redis.call('ZADD', KEYS[3], now + tonumber(ARGV[4]), ARGV[2])
+elseif ARGV[3] == 'front' then
+	redis.call('RPUSH', KEYS[3], ARGV[2])
+else
+	redis.call('LPUSH', KEYS[3], ARGV[2])
+end
+return 1
+`)
+
+// expiredScript는 처리 중 리스트에서 가시성 타임아웃이 지난 작업을 반환합니다
+//
+// 임대 기록이 없는 작업(꺼낸 직후 죽은 경우)은 지금부터 타임아웃을 적용합니다
+//
+// KEYS[1]: 처리 중 리스트, KEYS[2]: 임대 해시, ARGV: 가시성 타임아웃(ms)
+var expiredScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local expired = {}
+for _, job in ipairs(redis.call('LRANGE', KEYS[1], 0, -1)) do
+	local deadline = redis.call('HGET', KEYS[2], job)
+	if not deadline then
+		redis.call('HSET', KEYS[2], job, now + tonumber(ARGV[1]))
+	elseif tonumber(deadline) <= now then
+		table.insert(expired, job)
+	end
+end
+return expired
+`)
+
+// statsScript는 대기, 예약, 데드 레터 작업 수를 반환합니다
+//
+// KEYS[1]: 대기 리스트, KEYS[2]: 예약 ZSET, KEYS[3]: 데드 레터 리스트
+var statsScript = redis.NewScript(`
+return {redis.call('LLEN', KEYS[1]), redis.call('ZCARD', KEYS[2]), redis.call('LLEN', KEYS[3])}
+`)
@@ -0,0 +1,163 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// localSweepInterval은 로컬 제한기가 더 이상 필요 없는 키 상태를 정리하는 주기입니다
+const localSweepInterval = time.Minute
+
+// localLimiter는 Redis에 연결할 수 없을 때 사용하는 프로세스 내부 제한기입니다
+//
+// Redis 스크립트와 같은 규칙으로 판정합니다. Redis 키의 TTL처럼, 만료된 윈도우와 비어 있는
+// 요청 기록, 가득 찬 버킷은 localSweepInterval마다 판정 중에 정리하므로 키가 많아도
+// 메모리가 계속 늘어나지 않습니다
+type localLimiter struct {
+	algorithm Algorithm
+
+	mu        sync.Mutex
+	windows   map[string]*localWindow
+	logs      map[string]*localLog
+	buckets   map[string]*localBucket
+	nextSweep time.Time
+}
+
+type localWindow struct {
+	count   int64
+	expires time.Time
+}
+
+// localLog의 expires는 마지막 요청이 윈도우를 벗어나는 시각입니다
+type localLog struct {
+	entries []time.Time
+	expires time.Time
+}
+
+// localBucket의 full은 버킷이 다시 가득 차는 시각입니다
+type localBucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time
+}
+
+func newLocalLimiter(algorithm Algorithm) *localLimiter {
+	return &localLimiter{
+		algorithm: algorithm,
+		windows:   make(map[string]*localWindow),
+		logs:      make(map[string]*localLog),
+		buckets:   make(map[string]*localBucket),
+	}
+}
+
+func (l *localLimiter) allow(key string, limit Limit, n int64, now time.Time) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !now.Before(l.nextSweep) {
+		l.sweep(now)
+		l.nextSweep = now.Add(localSweepInterval)
+	}
+
+	res := Result{Limit: limit, Local: true}
+	switch l.algorithm {
+	case FixedWindow:
+		w, ok := l.windows[key]
+		if !ok || !now.Before(w.expires) {
+			w = &localWindow{expires: now.Add(limit.Period)}
+			l.windows[key] = w
+		}
+		if w.count+n > limit.Rate {
+			res.Remaining = max(limit.Rate-w.count, 0)
+			res.RetryAfter = w.expires.Sub(now)
+			return res
+		}
+		w.count += n
+		res.Allowed = true
+		res.Remaining = limit.Rate - w.count
+
+	case SlidingWindowLog:
+		// 윈도우를 벗어난 요청 기록 제거
+		log, ok := l.logs[key]
+		if !ok {
+			log = &localLog{}
+			l.logs[key] = log
+		}
+		entries := log.entries
+		cutoff := now.Add(-limit.Period)
+		i := 0
+		for i < len(entries) && !entries[i].After(cutoff) {
+			i++
+		}
+		entries = entries[i:]
+
+		count := int64(len(entries))
+		if count+n > limit.Rate {
+			res.Remaining = max(limit.Rate-count, 0)
+			res.RetryAfter = entries[count+n-limit.Rate-1].Add(limit.Period).Sub(now)
+			log.entries = entries
+			return res
+		}
+		for j := int64(0); j < n; j++ {
+			entries = append(entries, now)
+		}
+		log.entries = entries
+		log.expires = now.Add(limit.Period)
+		res.Allowed = true
+		res.Remaining = limit.Rate - count - n
+
+	case TokenBucket:
+		capacity := float64(limit.capacity(TokenBucket))
+		perNs := float64(limit.Rate) / float64(limit.Period)
+
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &localBucket{tokens: capacity, last: now}
+			l.buckets[key] = b
+		}
+		b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.last))*perNs)
+		b.last = now
+
+		if b.tokens >= float64(n) {
+			b.tokens -= float64(n)
+			res.Allowed = true
+		} else {
+			res.RetryAfter = time.Duration(math.Ceil((float64(n) - b.tokens) / perNs))
+		}
+		b.full = now.Add(time.Duration(math.Ceil((capacity - b.tokens) / perNs)))
+		res.Remaining = int64(b.tokens)
+	}
+	return res
+}
+
+// sweep은 없는 것과 같은 상태가 된 키를 제거합니다
+//
+// 만료된 윈도우, 모든 요청이 윈도우를 벗어난 기록, 가득 찬 버킷은 다음 요청에서 새로 만든
+// 상태와 판정 결과가 같습니다
+func (l *localLimiter) sweep(now time.Time) {
+	for key, w := range l.windows {
+		if !now.Before(w.expires) {
+			delete(l.windows, key)
+		}
+	}
+	for key, log := range l.logs {
+		if !now.Before(log.expires) {
+			delete(l.logs, key)
+		}
+	}
+	for key, b := range l.buckets {
+		if !now.Before(b.full) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *localLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.windows, key)
+	delete(l.logs, key)
+	delete(l.buckets, key)
+}
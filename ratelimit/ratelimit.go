@@ -0,0 +1,275 @@
+// Package ratelimit은 Redis Lua 스크립트로 원자적으로 동작하는 요청 속도 제한기를 제공합니다
+//
+// 고정 윈도우, 슬라이딩 윈도우 로그, 토큰 버킷 알고리즘을 지원하며,
+// Redis에 연결할 수 없을 때는 프로세스 내부 제한기로 대체할 수 있습니다
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"testcontainers-learning/redis"
+)
+
+// DefaultPrefix는 제한기 키의 기본 접두사입니다
+const DefaultPrefix = "ratelimit"
+
+var (
+	// ErrInvalidLimit는 Rate가 0 이하이거나 Period가 1ms 미만인 Limit로 호출했을 때 반환됩니다
+	ErrInvalidLimit = errors.New("invalid rate limit")
+	// ErrExceedsLimit는 한 번에 요청한 수가 한도(버스트)보다 커서 절대 허용될 수 없을 때 반환됩니다
+	ErrExceedsLimit = errors.New("request exceeds rate limit capacity")
+)
+
+// Algorithm은 속도 제한 알고리즘입니다
+type Algorithm int
+
+const (
+	// FixedWindow는 첫 요청부터 Period 동안 Rate개까지 허용합니다
+	FixedWindow Algorithm = iota
+	// SlidingWindowLog는 최근 Period 동안의 요청을 기록해 Rate개까지 허용합니다
+	SlidingWindowLog
+	// TokenBucket은 Period마다 Rate개씩 충전되는 최대 Burst개의 토큰을 소비합니다
+	TokenBucket
+)
+
+// String은 알고리즘 이름을 반환합니다
+func (a Algorithm) String() string {
+	switch a {
+	case FixedWindow:
+		return "fixed_window"
+	case SlidingWindowLog:
+		return "sliding_window_log"
+	case TokenBucket:
+		return "token_bucket"
+	default:
+		return "algorithm(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// Limit은 Period 동안 허용할 요청 수입니다
+type Limit struct {
+	// Rate는 Period 동안 허용할 요청 수입니다
+	Rate int64
+	// Period는 제한 기간입니다
+	Period time.Duration
+	// Burst는 토큰 버킷의 최대 토큰 수입니다 (0이면 Rate)
+	Burst int64
+}
+
+// PerSecond는 초당 rate개를 허용하는 Limit을 만듭니다
+func PerSecond(rate int64) Limit {
+	return Limit{Rate: rate, Period: time.Second}
+}
+
+// PerMinute는 분당 rate개를 허용하는 Limit을 만듭니다
+func PerMinute(rate int64) Limit {
+	return Limit{Rate: rate, Period: time.Minute}
+}
+
+// capacity는 한 번에 허용할 수 있는 최대 요청 수입니다
+func (l Limit) capacity(alg Algorithm) int64 {
+	if alg == TokenBucket && l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// Result는 속도 제한 판정 결과입니다
+type Result struct {
+	// Allowed는 요청이 허용되었는지 나타냅니다
+	Allowed bool
+	// Limit은 적용된 한도입니다
+	Limit Limit
+	// Remaining은 지금 추가로 허용 가능한 요청 수입니다
+	Remaining int64
+	// RetryAfter는 거부된 요청을 다시 시도할 수 있을 때까지의 시간입니다 (허용 시 0)
+	RetryAfter time.Duration
+	// Local은 Redis 대신 프로세스 내부 제한기로 판정했는지 나타냅니다
+	Local bool
+}
+
+// config는 Limiter 설정입니다
+type config struct {
+	prefix        string
+	limitFor      func(key string) Limit
+	localFallback bool
+	onFallback    func(error)
+}
+
+// Option은 Limiter 동작을 설정합니다
+type Option func(*config)
+
+// WithPrefix는 Redis 키 접두사를 설정합니다 (기본값 DefaultPrefix)
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// WithLimitFunc는 키별 한도를 정하는 함수를 설정합니다
+//
+// 함수가 Rate가 0인 Limit을 반환하면 기본 한도를 사용합니다
+func WithLimitFunc(fn func(key string) Limit) Option {
+	return func(c *config) {
+		c.limitFor = fn
+	}
+}
+
+// WithLocalFallback은 Redis에 연결할 수 없을 때 프로세스 내부 제한기로 판정하도록 합니다
+//
+// onFallback이 nil이 아니면 대체할 때마다 원인 에러와 함께 호출됩니다.
+// 내부 제한기는 프로세스별로 따로 세므로 전체 한도는 인스턴스 수만큼 느슨해질 수 있습니다
+func WithLocalFallback(onFallback func(error)) Option {
+	return func(c *config) {
+		c.localFallback = true
+		c.onFallback = onFallback
+	}
+}
+
+// Limiter는 Redis 기반 속도 제한기입니다
+type Limiter struct {
+	client    *redis.Client
+	algorithm Algorithm
+	limit     Limit
+	cfg       config
+	local     *localLimiter
+}
+
+// New는 기본 한도 limit으로 새로운 Limiter를 생성합니다
+func New(client *redis.Client, algorithm Algorithm, limit Limit, opts ...Option) (*Limiter, error) {
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+	if algorithm < FixedWindow || algorithm > TokenBucket {
+		return nil, fmt.Errorf("unknown rate limit algorithm %v", algorithm)
+	}
+
+	cfg := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Limiter{
+		client:    client,
+		algorithm: algorithm,
+		limit:     limit,
+		cfg:       cfg,
+		local:     newLocalLimiter(algorithm),
+	}, nil
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 || l.Period < time.Millisecond || l.Burst < 0 {
+		return fmt.Errorf("%w: rate %d per %v, burst %d", ErrInvalidLimit, l.Rate, l.Period, l.Burst)
+	}
+	return nil
+}
+
+// Allow는 key에 대한 요청 한 건을 허용할지 판정합니다
+func (lim *Limiter) Allow(ctx context.Context, key string) (Result, error) {
+	return lim.AllowN(ctx, key, 1)
+}
+
+// AllowN은 key에 대한 요청 n건을 한꺼번에 허용할지 판정합니다
+//
+// 거부된 요청은 한도에서 차감되지 않습니다
+func (lim *Limiter) AllowN(ctx context.Context, key string, n int64) (Result, error) {
+	limit := lim.limitFor(key)
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+	if n <= 0 || n > limit.capacity(lim.algorithm) {
+		return Result{Limit: limit}, fmt.Errorf("%w: %d of %d", ErrExceedsLimit, n, limit.capacity(lim.algorithm))
+	}
+
+	storeKey := lim.key(key)
+	res, err := lim.allowRedis(ctx, storeKey, limit, n)
+	if err != nil && lim.cfg.localFallback && isUnreachable(err) && ctx.Err() == nil {
+		if lim.cfg.onFallback != nil {
+			lim.cfg.onFallback(err)
+		}
+		return lim.local.allow(storeKey, limit, n, time.Now()), nil
+	}
+	return res, err
+}
+
+// Reset은 key의 제한 상태를 지웁니다
+func (lim *Limiter) Reset(ctx context.Context, key string) error {
+	storeKey := lim.key(key)
+	lim.local.reset(storeKey)
+	return lim.client.Delete(ctx, storeKey)
+}
+
+// limitFor는 key에 적용할 한도를 반환합니다
+func (lim *Limiter) limitFor(key string) Limit {
+	if lim.cfg.limitFor != nil {
+		if limit := lim.cfg.limitFor(key); limit.Rate != 0 {
+			return limit
+		}
+	}
+	return lim.limit
+}
+
+// key는 알고리즘별로 구분된 Redis 키를 만듭니다
+func (lim *Limiter) key(key string) string {
+	return fmt.Sprintf("%s:%s:%s", lim.cfg.prefix, lim.algorithm, key)
+}
+
+func (lim *Limiter) allowRedis(ctx context.Context, key string, limit Limit, n int64) (Result, error) {
+	var (
+		reply interface{}
+		err   error
+	)
+
+	keys := []string{key}
+	switch lim.algorithm {
+	case FixedWindow:
+		reply, err = lim.client.RunScript(ctx, fixedWindowScript, keys, limit.Rate, limit.Period.Milliseconds(), n)
+	case SlidingWindowLog:
+		var member string
+		if member, err = newMember(); err == nil {
+			reply, err = lim.client.RunScript(ctx, slidingWindowLogScript, keys, limit.Rate, limit.Period.Milliseconds(), n, member)
+		}
+	case TokenBucket:
+		perMs := float64(limit.Rate) / float64(limit.Period.Milliseconds())
+		reply, err = lim.client.RunScript(ctx, tokenBucketScript, keys, limit.capacity(TokenBucket), strconv.FormatFloat(perMs, 'g', -1, 64), n)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 3 {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply %v", reply)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	retryMs, _ := values[2].(int64)
+	return Result{
+		Allowed:    allowed == 1,
+		Limit:      limit,
+		Remaining:  remaining,
+		RetryAfter: time.Duration(retryMs) * time.Millisecond,
+	}, nil
+}
+
+// newMember는 슬라이딩 윈도우 로그에 기록할 고유한 멤버 접두사를 만듭니다
+func newMember() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// isUnreachable은 Redis 서버에 연결할 수 없어 발생한 에러인지 확인합니다
+func isUnreachable(err error) bool {
+	return errors.Is(err, redis.ErrConnection) || errors.Is(err, redis.ErrTimeout)
+}
@@ -0,0 +1,293 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"testcontainers-learning/redis"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Redis 컨테이너 시작 (모든 테스트에서 공유)
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	if err != nil {
+		panic(err)
+	}
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		_ = testcontainers.TerminateContainer(redisContainer)
+		panic(err)
+	}
+	testClient = redis.NewClient(endpoint)
+
+	// 테스트 실행
+	code := m.Run()
+
+	// 정리
+	testClient.Close()
+	if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+		panic(err)
+	}
+
+	os.Exit(code)
+}
+
+// newLimiter는 테스트마다 다른 접두사를 쓰는 Limiter를 만듭니다
+func newLimiter(t *testing.T, client *redis.Client, alg Algorithm, limit Limit, opts ...Option) *Limiter {
+	opts = append([]Option{WithPrefix("test:" + t.Name())}, opts...)
+	limiter, err := New(client, alg, limit, opts...)
+	require.NoError(t, err)
+	return limiter
+}
+
+func TestFixedWindow(t *testing.T) {
+	ctx := context.Background()
+	limiter := newLimiter(t, testClient, FixedWindow, Limit{Rate: 3, Period: 500 * time.Millisecond})
+
+	for i := int64(2); i >= 0; i-- {
+		res, err := limiter.Allow(ctx, "user:1")
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, i, res.Remaining)
+		assert.Zero(t, res.RetryAfter)
+	}
+
+	// 한도 초과 시 윈도우가 끝날 때까지 거부
+	res, err := limiter.Allow(ctx, "user:1")
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Zero(t, res.Remaining)
+	assert.Greater(t, res.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, res.RetryAfter, 500*time.Millisecond)
+
+	// 다른 키는 독립적으로 제한
+	res, err = limiter.Allow(ctx, "user:2")
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	// 윈도우가 지나면 다시 허용
+	time.Sleep(600 * time.Millisecond)
+	res, err = limiter.Allow(ctx, "user:1")
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, int64(2), res.Remaining)
+}
+
+func TestSlidingWindowLog(t *testing.T) {
+	ctx := context.Background()
+	limiter := newLimiter(t, testClient, SlidingWindowLog, Limit{Rate: 4, Period: 500 * time.Millisecond})
+
+	// 한 번에 여러 건 요청
+	res, err := limiter.AllowN(ctx, "api", 3)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, int64(1), res.Remaining)
+
+	time.Sleep(250 * time.Millisecond)
+	res, err = limiter.Allow(ctx, "api")
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Zero(t, res.Remaining)
+
+	// 첫 요청들이 윈도우를 벗어날 때까지 거부 (거부된 요청은 기록하지 않음)
+	res, err = limiter.AllowN(ctx, "api", 2)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Greater(t, res.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, res.RetryAfter, 250*time.Millisecond)
+
+	// 첫 요청들만 만료되면 그만큼 다시 허용
+	time.Sleep(res.RetryAfter + 50*time.Millisecond)
+	res, err = limiter.AllowN(ctx, "api", 2)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, int64(1), res.Remaining)
+
+	// 한도보다 큰 요청은 절대 허용될 수 없음
+	_, err = limiter.AllowN(ctx, "api", 5)
+	assert.ErrorIs(t, err, ErrExceedsLimit)
+}
+
+func TestTokenBucket(t *testing.T) {
+	ctx := context.Background()
+
+	// 초당 10개 충전, 최대 5개 버스트
+	limiter := newLimiter(t, testClient, TokenBucket, Limit{Rate: 10, Period: time.Second, Burst: 5})
+
+	res, err := limiter.AllowN(ctx, "client", 5)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Zero(t, res.Remaining)
+
+	// 토큰이 없으면 충전될 때까지 대기 (1개당 100ms)
+	res, err = limiter.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Greater(t, res.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, res.RetryAfter, 100*time.Millisecond)
+
+	time.Sleep(250 * time.Millisecond)
+	res, err = limiter.AllowN(ctx, "client", 2)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	// 오래 기다려도 Burst 이상은 쌓이지 않음
+	time.Sleep(time.Second)
+	_, err = limiter.AllowN(ctx, "client", 6)
+	assert.ErrorIs(t, err, ErrExceedsLimit)
+	res, err = limiter.AllowN(ctx, "client", 5)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestPerKeyLimits(t *testing.T) {
+	ctx := context.Background()
+	limiter := newLimiter(t, testClient, FixedWindow, PerMinute(1),
+		WithLimitFunc(func(key string) Limit {
+			if key == "premium" {
+				return PerMinute(3)
+			}
+			return Limit{}
+		}),
+	)
+
+	allowed := func(key string) int {
+		n := 0
+		for i := 0; i < 5; i++ {
+			res, err := limiter.Allow(ctx, key)
+			require.NoError(t, err)
+			if res.Allowed {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.Equal(t, 1, allowed("free"))
+	assert.Equal(t, 3, allowed("premium"))
+
+	// Reset 후 다시 허용
+	require.NoError(t, limiter.Reset(ctx, "free"))
+	assert.Equal(t, 1, allowed("free"))
+}
+
+func TestRateLimitConcurrency(t *testing.T) {
+	ctx := context.Background()
+
+	for _, alg := range []Algorithm{FixedWindow, SlidingWindowLog, TokenBucket} {
+		t.Run(alg.String(), func(t *testing.T) {
+			limiter := newLimiter(t, testClient, alg, PerMinute(25))
+
+			// 여러 고루틴이 동시에 요청해도 정확히 한도만큼만 허용
+			var allowed atomic.Int32
+			var wg sync.WaitGroup
+			for i := 0; i < 100; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					res, err := limiter.Allow(ctx, "shared")
+					assert.NoError(t, err)
+					if res.Allowed {
+						allowed.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, int32(25), allowed.Load())
+		})
+	}
+}
+
+func TestLocalFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// 아무것도 수신하지 않는 주소
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := redis.NewClient(addr)
+	defer client.Close()
+
+	// 대체하지 않으면 에러 반환
+	limiter := newLimiter(t, client, FixedWindow, PerMinute(2))
+	_, err = limiter.Allow(ctx, "user")
+	assert.Error(t, err)
+
+	for _, alg := range []Algorithm{FixedWindow, SlidingWindowLog, TokenBucket} {
+		var fallbacks atomic.Int32
+		limiter := newLimiter(t, client, alg, PerMinute(2), WithLocalFallback(func(err error) {
+			fallbacks.Add(1)
+		}))
+
+		for i := 0; i < 2; i++ {
+			res, err := limiter.Allow(ctx, "user")
+			require.NoError(t, err, alg)
+			assert.True(t, res.Allowed, alg)
+			assert.True(t, res.Local, alg)
+		}
+
+		res, err := limiter.Allow(ctx, "user")
+		require.NoError(t, err, alg)
+		assert.False(t, res.Allowed, alg)
+		assert.Greater(t, res.RetryAfter, time.Duration(0), alg)
+		assert.Equal(t, int32(3), fallbacks.Load(), alg)
+	}
+}
+
+func TestLocalLimiterSweep(t *testing.T) {
+	now := time.Now()
+	limit := PerSecond(2)
+
+	for _, alg := range []Algorithm{FixedWindow, SlidingWindowLog, TokenBucket} {
+		local := newLocalLimiter(alg)
+		size := func() int {
+			return len(local.windows) + len(local.logs) + len(local.buckets)
+		}
+
+		// 키마다 상태가 생김
+		for i := range 100 {
+			local.allow(fmt.Sprintf("ip:%d", i), limit, 1, now)
+		}
+		assert.Equal(t, 100, size(), alg)
+
+		// 정리 주기 전에는 그대로 유지
+		local.allow("ip:0", limit, 1, now.Add(2*time.Second))
+		assert.Equal(t, 100, size(), alg)
+
+		// 정리 주기가 지나면 만료된 윈도우, 비어 있는 기록, 가득 찬 버킷을 제거
+		res := local.allow("ip:new", limit, 1, now.Add(localSweepInterval))
+		assert.True(t, res.Allowed, alg)
+		assert.Equal(t, 1, size(), alg)
+	}
+}
+
+func TestInvalidLimit(t *testing.T) {
+	_, err := New(testClient, FixedWindow, Limit{Rate: 0, Period: time.Second})
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = New(testClient, TokenBucket, Limit{Rate: 1, Period: time.Microsecond})
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = New(testClient, Algorithm(99), PerSecond(1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import "testcontainers-learning/redis"
+
+// 모든 스크립트는 {allowed, remaining, retryAfterMs}를 반환합니다
+
+// fixedWindowScript는 첫 요청부터 window 동안 요청 수를 셉니다
+//
+// KEYS[1]: 카운터, ARGV: limit, window(ms), n
+var fixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local current = redis.call('INCRBY', KEYS[1], n)
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], window)
+	ttl = window
+end
+
+if current > limit then
+	-- 거부된 요청은 세지 않음
+	redis.call('DECRBY', KEYS[1], n)
+	return {0, math.max(limit - current + n, 0), ttl}
+end
+return {1, limit - current, 0}
+`)
+
+// slidingWindowLogScript는 최근 window 동안의 요청 시각을 ZSET에 기록합니다
+//
+// KEYS[1]: 요청 로그, ARGV: limit, window(ms), n, 멤버 접두사
+var slidingWindowLogScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+
+if count + n <= limit then
+	for i = 1, n do
+		redis.call('ZADD', KEYS[1], now, ARGV[4] .. ':' .. i)
+	end
+	redis.call('PEXPIRE', KEYS[1], window)
+	return {1, limit - count - n, 0}
+end
+
+-- n개가 들어갈 자리가 생기려면 가장 오래된 요청부터 몇 개가 만료되어야 하는지 계산
+local retry = window
+local expire = count + n - limit
+if n <= limit and expire <= count then
+	local entry = redis.call('ZRANGE', KEYS[1], expire - 1, expire - 1, 'WITHSCORES')
+	retry = tonumber(entry[2]) + window - now
+end
+return {0, math.max(limit - count, 0), retry}
+`)
+
+// tokenBucketScript는 경과 시간만큼 토큰을 채운 뒤 n개를 소비합니다
+//
+// KEYS[1]: 버킷 해시, ARGV: capacity, 밀리초당 충전량, n
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+tokens = math.min(capacity, tokens + math.max(now - ts, 0) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= n then
+	tokens = tokens - n
+	allowed = 1
+elseif n <= capacity then
+	retry = math.ceil((n - tokens) / rate)
+else
+	retry = -1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate) + 1000)
+return {allowed, math.floor(tokens), retry}
+`)
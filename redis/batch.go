@@ -0,0 +1,356 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WATCH 재시도 기본 설정값
+const (
+	DefaultWatchAttempts       = 10
+	DefaultWatchInitialBackoff = 5 * time.Millisecond
+	DefaultWatchMaxBackoff     = 100 * time.Millisecond
+)
+
+var (
+	// ErrBatchNotExecuted는 Exec 전에 명령 결과를 읽었을 때 반환됩니다
+	ErrBatchNotExecuted = errors.New("batch not executed")
+	// ErrTxConflict는 WATCH한 키가 다른 클라이언트에 의해 변경되어 트랜잭션이 취소되었을 때 반환됩니다
+	ErrTxConflict = errors.New("transaction aborted: watched key changed")
+)
+
+// batchState는 한 번의 Exec로 실행되는 명령 묶음의 상태입니다
+type batchState struct {
+	executed bool
+	// aborted는 명령을 보내지 않고 끝난 이유입니다
+	aborted error
+}
+
+// Result는 Batch에 추가한 명령 하나의 결과입니다
+//
+// Batch의 Exec가 끝난 뒤에 읽을 수 있으며, 그 전에는 ErrBatchNotExecuted를 반환합니다
+type Result[T any] struct {
+	state *batchState
+	read  func() (T, error)
+}
+
+// Result는 명령의 결과 값과 에러를 반환합니다
+func (r *Result[T]) Result() (T, error) {
+	if !r.state.executed {
+		var zero T
+		return zero, ErrBatchNotExecuted
+	}
+	if r.state.aborted != nil {
+		var zero T
+		return zero, r.state.aborted
+	}
+	v, err := r.read()
+	return v, mapError(err)
+}
+
+// Val은 명령의 결과 값을 반환합니다 (에러가 있으면 zero 값)
+func (r *Result[T]) Val() T {
+	v, _ := r.Result()
+	return v
+}
+
+// Err는 명령의 에러를 반환합니다
+func (r *Result[T]) Err() error {
+	_, err := r.Result()
+	return err
+}
+
+// Batch는 여러 명령을 모아 한 번의 왕복으로 보냅니다
+//
+// Pipeline으로 만들면 명령을 순서대로 실행하고, TxPipeline이나 Tx.Multi로 만들면
+// MULTI/EXEC로 감싸 원자적으로 실행합니다. 동시에 여러 고루틴에서 사용하면 안 됩니다
+type Batch struct {
+	c     *Client
+	pipe  redis.Pipeliner
+	state *batchState
+	err   error
+}
+
+// Pipeline은 명령을 모아 한 번에 보내는 Batch를 생성합니다
+func (c *Client) Pipeline() *Batch {
+	return c.newBatch(c.rdb.Pipeline())
+}
+
+// TxPipeline은 명령을 MULTI/EXEC 트랜잭션으로 실행하는 Batch를 생성합니다
+func (c *Client) TxPipeline() *Batch {
+	return c.newBatch(c.rdb.TxPipeline())
+}
+
+func (c *Client) newBatch(pipe redis.Pipeliner) *Batch {
+	return &Batch{c: c, pipe: pipe, state: &batchState{}}
+}
+
+// Len은 Exec를 기다리는 명령 수를 반환합니다
+func (b *Batch) Len() int {
+	return b.pipe.Len()
+}
+
+// Exec는 모은 명령을 실행하고 각 Result를 채웁니다
+//
+// 연결 에러나 명령 에러(WRONGTYPE 등)가 있으면 첫 번째 에러를 반환하지만, 키가 없는 것(ErrNotFound)은
+// 에러로 보지 않습니다. 트랜잭션이 WATCH 충돌로 취소되면 ErrTxConflict를 반환합니다.
+// Exec 후에는 Batch를 다시 사용할 수 있습니다
+func (b *Batch) Exec(ctx context.Context) error {
+	state, encodeErr := b.state, b.err
+	b.state, b.err = &batchState{}, nil
+	defer func() { state.executed = true }()
+
+	// 인코딩에 실패한 명령이 있으면 일부만 쓰지 않도록 아무것도 보내지 않음
+	if encodeErr != nil {
+		b.pipe.Discard()
+		state.aborted = encodeErr
+		return encodeErr
+	}
+
+	cmds, err := b.pipe.Exec(ctx)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, redis.TxFailedErr) {
+		// 명령이 실행되지 않았으므로 각 Result도 ErrTxConflict를 반환
+		state.aborted = ErrTxConflict
+		return ErrTxConflict
+	}
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+// Set은 키-값 저장을 추가합니다 (결과 값은 "OK")
+func (b *Batch) Set(key string, value interface{}, expiration time.Duration) *Result[string] {
+	return addCmd(b, b.pipe.Set(context.Background(), b.c.key(key), value, expiration))
+}
+
+// Get은 키 조회를 추가합니다 (키가 없으면 결과 에러가 ErrNotFound)
+func (b *Batch) Get(key string) *Result[string] {
+	return addCmd(b, b.pipe.Get(context.Background(), b.c.key(key)))
+}
+
+// Delete는 키 삭제를 추가합니다 (결과 값은 삭제된 키 수)
+//
+// Cluster에서는 keys가 같은 슬롯에 있어야 합니다
+func (b *Batch) Delete(keys ...string) *Result[int64] {
+	return addCmd(b, b.pipe.Del(context.Background(), b.c.keys(keys)...))
+}
+
+// Exists는 키 존재 여부 확인을 추가합니다 (결과 값은 존재하는 키 수)
+//
+// Cluster에서는 keys가 같은 슬롯에 있어야 합니다
+func (b *Batch) Exists(keys ...string) *Result[int64] {
+	return addCmd(b, b.pipe.Exists(context.Background(), b.c.keys(keys)...))
+}
+
+// Expire는 TTL 설정을 추가합니다 (키가 없으면 결과 값이 false)
+func (b *Batch) Expire(key string, expiration time.Duration) *Result[bool] {
+	return addCmd(b, b.pipe.Expire(context.Background(), b.c.key(key), expiration))
+}
+
+// Increment는 원자적 증가를 추가합니다
+func (b *Batch) Increment(key string) *Result[int64] {
+	return addCmd(b, b.pipe.Incr(context.Background(), b.c.key(key)))
+}
+
+// Decrement는 원자적 감소를 추가합니다
+func (b *Batch) Decrement(key string) *Result[int64] {
+	return addCmd(b, b.pipe.Decr(context.Background(), b.c.key(key)))
+}
+
+// HSet은 해시 필드 저장을 추가합니다 (결과 값은 새로 추가된 필드 수)
+func (b *Batch) HSet(key string, values ...interface{}) *Result[int64] {
+	return addCmd(b, b.pipe.HSet(context.Background(), b.c.key(key), values...))
+}
+
+// HGet은 해시 필드 조회를 추가합니다
+func (b *Batch) HGet(key, field string) *Result[string] {
+	return addCmd(b, b.pipe.HGet(context.Background(), b.c.key(key), field))
+}
+
+// HGetAll은 해시 전체 조회를 추가합니다
+func (b *Batch) HGetAll(key string) *Result[map[string]string] {
+	return addCmd(b, b.pipe.HGetAll(context.Background(), b.c.key(key)))
+}
+
+// LPush는 리스트 왼쪽 추가를 추가합니다 (결과 값은 리스트 길이)
+func (b *Batch) LPush(key string, values ...interface{}) *Result[int64] {
+	return addCmd(b, b.pipe.LPush(context.Background(), b.c.key(key), values...))
+}
+
+// RPush는 리스트 오른쪽 추가를 추가합니다 (결과 값은 리스트 길이)
+func (b *Batch) RPush(key string, values ...interface{}) *Result[int64] {
+	return addCmd(b, b.pipe.RPush(context.Background(), b.c.key(key), values...))
+}
+
+// LRange는 리스트 범위 조회를 추가합니다
+func (b *Batch) LRange(key string, start, stop int64) *Result[[]string] {
+	return addCmd(b, b.pipe.LRange(context.Background(), b.c.key(key), start, stop))
+}
+
+// Publish는 메시지 발행을 추가합니다 (결과 값은 받은 구독자 수)
+func (b *Batch) Publish(channel string, message interface{}) *Result[int64] {
+	return addCmd(b, b.pipe.Publish(context.Background(), b.c.key(channel), message))
+}
+
+// BatchSetValue는 클라이언트의 Codec으로 인코딩한 값의 저장을 Batch에 추가합니다
+//
+// 인코딩에 실패하면 Exec가 명령을 보내지 않고 그 에러를 반환합니다
+func BatchSetValue[T any](b *Batch, key string, value T, expiration time.Duration) *Result[string] {
+	data, err := b.c.codec.Marshal(value)
+	if err != nil {
+		err = fmt.Errorf("encode %s: %w", key, err)
+		if b.err == nil {
+			b.err = err
+		}
+		return &Result[string]{state: b.state}
+	}
+	return b.Set(key, data, expiration)
+}
+
+// BatchGetValue는 클라이언트의 Codec으로 디코딩할 값의 조회를 Batch에 추가합니다
+func BatchGetValue[T any](b *Batch, key string) *Result[T] {
+	cmd := b.pipe.Get(context.Background(), b.c.key(key))
+	codec := b.c.codec
+	return &Result[T]{state: b.state, read: func() (T, error) {
+		var v T
+		data, err := cmd.Bytes()
+		if err != nil {
+			return v, err
+		}
+		err = codec.Unmarshal(data, &v)
+		return v, err
+	}}
+}
+
+// addCmd는 파이프라인에 추가된 go-redis 명령을 Result로 감쌉니다
+func addCmd[T any](b *Batch, cmd interface{ Result() (T, error) }) *Result[T] {
+	return &Result[T]{state: b.state, read: cmd.Result}
+}
+
+// watchConfig는 Watch 재시도 설정입니다
+type watchConfig struct {
+	attempts       int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// WatchOption은 Watch 재시도 동작을 설정합니다
+type WatchOption func(*watchConfig)
+
+// WithWatchAttempts는 충돌 시 최대 시도 횟수를 설정합니다 (기본값 DefaultWatchAttempts)
+func WithWatchAttempts(n int) WatchOption {
+	return func(c *watchConfig) {
+		c.attempts = n
+	}
+}
+
+// WithWatchBackoff는 충돌 후 재시도할 때의 대기 시간 범위를 설정합니다
+func WithWatchBackoff(initial, max time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		c.initialBackoff = initial
+		c.maxBackoff = max
+	}
+}
+
+// Tx는 WATCH 중인 연결입니다
+//
+// 읽기 명령은 WATCH한 연결에서 실행되고, Multi로 만든 Batch는 MULTI/EXEC로 실행됩니다
+type Tx struct {
+	c  *Client
+	tx *redis.Tx
+}
+
+// Watch는 keys를 WATCH한 상태에서 fn을 실행하는 낙관적 잠금 트랜잭션입니다
+//
+// fn은 현재 값을 읽고 tx.Multi()로 만든 Batch를 Exec해야 합니다. 그 사이에 다른 클라이언트가
+// keys를 변경하면 ErrTxConflict로 취소되고, 지터를 적용한 지수 백오프 후 fn을 처음부터 다시 실행합니다.
+// 최대 시도 횟수를 넘기면 ErrTxConflict를 감싼 에러를 반환합니다
+func (c *Client) Watch(ctx context.Context, keys []string, fn func(tx *Tx) error, opts ...WatchOption) error {
+	cfg := &watchConfig{
+		attempts:       DefaultWatchAttempts,
+		initialBackoff: DefaultWatchInitialBackoff,
+		maxBackoff:     DefaultWatchMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			return fn(&Tx{c: c, tx: tx})
+		}, c.keys(keys)...)
+		if errors.Is(err, redis.TxFailedErr) {
+			err = ErrTxConflict
+		}
+		if !errors.Is(err, ErrTxConflict) {
+			return mapError(err)
+		}
+		if attempt >= cfg.attempts {
+			return fmt.Errorf("watch %v: %d attempts: %w", keys, attempt, err)
+		}
+
+		timer := time.NewTimer(backoff(cfg.initialBackoff, cfg.maxBackoff, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Multi는 WATCH한 연결에서 MULTI/EXEC로 실행할 Batch를 생성합니다
+func (t *Tx) Multi() *Batch {
+	return t.c.newBatch(t.tx.TxPipeline())
+}
+
+// Get은 키에 해당하는 값을 조회합니다
+func (t *Tx) Get(ctx context.Context, key string) (string, error) {
+	v, err := t.tx.Get(ctx, t.c.key(key)).Result()
+	return v, mapError(err)
+}
+
+// Exists는 키의 존재 여부를 확인합니다
+func (t *Tx) Exists(ctx context.Context, keys ...string) (int64, error) {
+	v, err := t.tx.Exists(ctx, t.c.keys(keys)...).Result()
+	return v, mapError(err)
+}
+
+// HGet은 해시 필드의 값을 조회합니다
+func (t *Tx) HGet(ctx context.Context, key, field string) (string, error) {
+	v, err := t.tx.HGet(ctx, t.c.key(key), field).Result()
+	return v, mapError(err)
+}
+
+// HGetAll은 해시의 모든 필드와 값을 조회합니다
+func (t *Tx) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	v, err := t.tx.HGetAll(ctx, t.c.key(key)).Result()
+	return v, mapError(err)
+}
+
+// LRange는 리스트의 범위를 조회합니다
+func (t *Tx) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	v, err := t.tx.LRange(ctx, t.c.key(key), start, stop).Result()
+	return v, mapError(err)
+}
+
+// TxGetValue는 WATCH한 연결에서 클라이언트의 Codec으로 저장된 값을 T로 디코딩해 조회합니다
+func TxGetValue[T any](ctx context.Context, t *Tx, key string) (T, error) {
+	var v T
+	data, err := t.tx.Get(ctx, t.c.key(key)).Bytes()
+	if err != nil {
+		return v, mapError(err)
+	}
+	err = t.c.codec.Unmarshal(data, &v)
+	return v, err
+}
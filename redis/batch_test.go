@@ -0,0 +1,181 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisPipeline(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "batch:a", "batch:b", "batch:counter", "batch:hash", "batch:missing")
+
+	b := client.Pipeline()
+	setA := b.Set("batch:a", "1", 0)
+	setB := b.Set("batch:b", "2", 0)
+	incr := b.Increment("batch:counter")
+	hset := b.HSet("batch:hash", "name", "redis", "port", 6379)
+	expire := b.Expire("batch:a", time.Minute)
+	expireMissing := b.Expire("batch:missing", time.Minute)
+	get := b.Get("batch:b")
+	missing := b.Get("batch:missing")
+	assert.Equal(t, 8, b.Len())
+
+	// Exec 전에는 결과를 읽을 수 없음
+	assert.ErrorIs(t, get.Err(), ErrBatchNotExecuted)
+
+	// 없는 키는 Exec 에러가 아님
+	require.NoError(t, b.Exec(ctx))
+	assert.Zero(t, b.Len())
+
+	assert.Equal(t, "OK", setA.Val())
+	assert.NoError(t, setB.Err())
+	assert.Equal(t, int64(1), incr.Val())
+	assert.Equal(t, int64(2), hset.Val())
+	assert.True(t, expire.Val())
+	assert.False(t, expireMissing.Val())
+	assert.Equal(t, "2", get.Val())
+	_, err := missing.Result()
+	assert.ErrorIs(t, err, goredis.Nil)
+
+	// 같은 Batch를 다시 사용
+	hgetAll := b.HGetAll("batch:hash")
+	wrongType := b.HGet("batch:a", "field")
+	lpush := b.LPush("batch:list", "x")
+	require.Error(t, b.Exec(ctx))
+	assert.Equal(t, map[string]string{"name": "redis", "port": "6379"}, hgetAll.Val())
+	assert.ErrorContains(t, wrongType.Err(), "WRONGTYPE")
+	assert.Equal(t, int64(1), lpush.Val())
+	_ = client.Delete(ctx, "batch:list")
+
+	// 이전 결과는 그대로 유지
+	assert.Equal(t, "2", get.Val())
+}
+
+func TestRedisTxPipeline(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	type item struct {
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "tx:item:1", "tx:item:2", "tx:count")
+
+	b := client.TxPipeline()
+	for i := 1; i <= 2; i++ {
+		BatchSetValue(b, "tx:item:"+strconv.Itoa(i), item{Name: "item" + strconv.Itoa(i), Price: i * 100}, time.Minute)
+		b.Increment("tx:count")
+	}
+	first := BatchGetValue[item](b, "tx:item:1")
+	count := b.Get("tx:count")
+	require.NoError(t, b.Exec(ctx))
+
+	assert.Equal(t, item{Name: "item1", Price: 100}, first.Val())
+	assert.Equal(t, "2", count.Val())
+
+	// 인코딩에 실패하면 아무 명령도 보내지 않음
+	b.Increment("tx:count")
+	bad := BatchSetValue(b, "tx:bad", map[string]interface{}{"ch": make(chan int)}, 0)
+	err := b.Exec(ctx)
+	require.Error(t, err)
+	assert.Equal(t, err, bad.Err())
+
+	value, err := client.Get(ctx, "tx:count")
+	require.NoError(t, err)
+	assert.Equal(t, "2", value)
+}
+
+func TestRedisWatch(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	require.NoError(t, client.Set(ctx, "watch:balance", "0", 0))
+
+	// 동시에 read-modify-write해도 충돌한 트랜잭션은 다시 실행되어 갱신이 유실되지 않음
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				err := client.Watch(ctx, []string{"watch:balance"}, func(tx *Tx) error {
+					value, err := tx.Get(ctx, "watch:balance")
+					if err != nil {
+						return err
+					}
+					balance, err := strconv.Atoi(value)
+					if err != nil {
+						return err
+					}
+
+					b := tx.Multi()
+					b.Set("watch:balance", balance+10, 0)
+					return b.Exec(ctx)
+				}, WithWatchAttempts(1000))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, err := client.Get(ctx, "watch:balance")
+	require.NoError(t, err)
+	assert.Equal(t, "1000", value)
+}
+
+func TestRedisWatchConflict(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	require.NoError(t, SetValue(ctx, client, "watch:stock", 5, 0))
+
+	// 매번 다른 클라이언트가 끼어들면 최대 시도 횟수 후 실패
+	attempts := 0
+	var set *Result[string]
+	err := client.Watch(ctx, []string{"watch:stock"}, func(tx *Tx) error {
+		attempts++
+		stock, err := TxGetValue[int](ctx, tx, "watch:stock")
+		if err != nil {
+			return err
+		}
+		require.NoError(t, client.Set(ctx, "watch:stock", stock+1, 0))
+
+		b := tx.Multi()
+		set = b.Set("watch:stock", stock-1, 0)
+		return b.Exec(ctx)
+	}, WithWatchAttempts(3), WithWatchBackoff(time.Millisecond, time.Millisecond))
+	assert.ErrorIs(t, err, ErrTxConflict)
+	assert.Equal(t, 3, attempts)
+
+	// 취소된 트랜잭션의 명령 결과도 ErrTxConflict
+	_, err = set.Result()
+	assert.ErrorIs(t, err, ErrTxConflict)
+	assert.ErrorIs(t, set.Err(), ErrTxConflict)
+
+	stock, err := GetValue[int](ctx, client, "watch:stock")
+	require.NoError(t, err)
+	assert.Equal(t, 8, stock)
+
+	// fn의 에러는 재시도하지 않고 그대로 반환
+	attempts = 0
+	err = client.Watch(ctx, []string{"watch:stock"}, func(tx *Tx) error {
+		attempts++
+		_, err := tx.HGet(ctx, "watch:stock", "field")
+		return err
+	})
+	assert.ErrorContains(t, err, "WRONGTYPE")
+	assert.Equal(t, 1, attempts)
+}
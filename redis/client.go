@@ -2,96 +2,253 @@ package redis
 
 import (
 	"context"
+	"iter"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
 // Client는 Redis 클라이언트를 래핑합니다
+//
+// 단일 노드, Sentinel, Cluster 중 어떤 방식으로 연결해도 같은 메서드를 사용합니다 (New 참고)
 type Client struct {
-	rdb *redis.Client
+	rdb   redis.UniversalClient
+	codec Codec
+
+	// prefix는 Namespace로 만든 뷰에서 모든 키 앞에 붙이는 접두사입니다
+	prefix string
+	// view는 Namespace로 만든 뷰인지 여부입니다 (연결은 원본 클라이언트가 소유)
+	view bool
 }
 
-// NewClient는 새로운 Redis 클라이언트를 생성합니다
+// NewClient는 addr의 단일 노드에 연결하는 Redis 클라이언트를 생성합니다
 func NewClient(addr string) *Client {
 	return &Client{
 		rdb: redis.NewClient(&redis.Options{
 			Addr: addr,
 		}),
+		codec: JSONCodec{},
 	}
 }
 
+// SetCodec은 SetValue/GetValue에서 사용할 Codec을 변경합니다 (기본값은 JSONCodec)
+func (c *Client) SetCodec(codec Codec) {
+	c.codec = codec
+}
+
 // Close는 Redis 연결을 종료합니다
+//
+// Namespace로 만든 뷰는 연결을 공유하므로 아무것도 하지 않으며, 원본 클라이언트를 닫아야 합니다
 func (c *Client) Close() error {
+	if c.view {
+		return nil
+	}
 	return c.rdb.Close()
 }
 
 // Ping은 Redis 서버 연결을 확인합니다
 func (c *Client) Ping(ctx context.Context) error {
-	return c.rdb.Ping(ctx).Err()
+	return mapError(c.rdb.Ping(ctx).Err())
 }
 
 // Set은 키-값을 저장합니다
 func (c *Client) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	return c.rdb.Set(ctx, key, value, expiration).Err()
+	return mapError(c.rdb.Set(ctx, c.key(key), value, expiration).Err())
 }
 
 // Get은 키에 해당하는 값을 조회합니다
 func (c *Client) Get(ctx context.Context, key string) (string, error) {
-	return c.rdb.Get(ctx, key).Result()
+	v, err := c.rdb.Get(ctx, c.key(key)).Result()
+	return v, mapError(err)
 }
 
 // Delete는 키를 삭제합니다
+//
+// Cluster에서는 키가 서로 다른 슬롯에 있을 수 있으므로 키마다 나누어 삭제합니다 (원자적이지 않음)
 func (c *Client) Delete(ctx context.Context, keys ...string) error {
-	return c.rdb.Del(ctx, keys...).Err()
+	if c.splitKeys(keys) {
+		_, err := c.perKey(ctx, c.keys(keys), redis.Pipeliner.Del)
+		return err
+	}
+	return mapError(c.rdb.Del(ctx, c.keys(keys)...).Err())
 }
 
 // Exists는 키의 존재 여부를 확인합니다
+//
+// Cluster에서는 키가 서로 다른 슬롯에 있을 수 있으므로 키마다 나누어 확인한 수를 합산합니다
 func (c *Client) Exists(ctx context.Context, keys ...string) (int64, error) {
-	return c.rdb.Exists(ctx, keys...).Result()
+	if c.splitKeys(keys) {
+		return c.perKey(ctx, c.keys(keys), redis.Pipeliner.Exists)
+	}
+	v, err := c.rdb.Exists(ctx, c.keys(keys)...).Result()
+	return v, mapError(err)
+}
+
+// splitKeys는 여러 키를 받는 명령을 키마다 나누어 실행해야 하는지 확인합니다 (Cluster)
+func (c *Client) splitKeys(keys []string) bool {
+	_, cluster := c.rdb.(*redis.ClusterClient)
+	return cluster && len(keys) > 1
+}
+
+// perKey는 키마다 cmd를 하나의 파이프라인으로 실행하고 결과를 합산합니다
+//
+// Cluster 파이프라인은 명령을 노드별로 나누어 보내므로 CROSSSLOT 에러가 나지 않습니다
+func (c *Client) perKey(ctx context.Context, keys []string, cmd func(redis.Pipeliner, context.Context, ...string) *redis.IntCmd) (int64, error) {
+	cmds := make([]*redis.IntCmd, len(keys))
+	_, err := c.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = cmd(pipe, ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	var total int64
+	for _, cmd := range cmds {
+		total += cmd.Val()
+	}
+	return total, nil
+}
+
+// Scan은 pattern과 일치하는 키를 SCAN으로 순회합니다
+//
+// count는 SCAN 한 번에 가져올 키 수의 힌트이며, 순회 중 추가되거나 삭제된 키는
+// 포함되지 않을 수도 있습니다 (같은 키가 두 번 나올 수도 있음).
+// Cluster에서는 모든 마스터 노드를 차례로 순회합니다.
+// Namespace 뷰에서는 네임스페이스 안의 키만 순회하며 접두사를 뗀 키를 반환합니다
+func (c *Client) Scan(ctx context.Context, pattern string, count int64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for key, err := range c.scan(ctx, escapeGlob(c.prefix)+pattern, count) {
+			if !yield(c.trimKey(key), err) {
+				return
+			}
+		}
+	}
+}
+
+// scan은 접두사를 붙이지 않은 pattern으로 모든 노드를 SCAN합니다
+func (c *Client) scan(ctx context.Context, pattern string, count int64) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		nodes, err := c.scanNodes(ctx)
+		if err != nil {
+			yield("", mapError(err))
+			return
+		}
+
+		for _, node := range nodes {
+			it := node.Scan(ctx, 0, pattern, count).Iterator()
+			for it.Next(ctx) {
+				if !yield(it.Val(), nil) {
+					return
+				}
+			}
+			if err := it.Err(); err != nil {
+				yield("", mapError(err))
+				return
+			}
+		}
+	}
+}
+
+// scanNodes는 SCAN을 실행할 노드 목록을 반환합니다 (Cluster이면 모든 마스터)
+func (c *Client) scanNodes(ctx context.Context) ([]redis.Cmdable, error) {
+	cluster, ok := c.rdb.(*redis.ClusterClient)
+	if !ok {
+		return []redis.Cmdable{c.rdb}, nil
+	}
+
+	var (
+		mu    sync.Mutex
+		nodes []redis.Cmdable
+	)
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+		nodes = append(nodes, node)
+		return nil
+	})
+	return nodes, err
 }
 
 // Expire는 키에 TTL을 설정합니다
 func (c *Client) Expire(ctx context.Context, key string, expiration time.Duration) error {
-	return c.rdb.Expire(ctx, key, expiration).Err()
+	return mapError(c.rdb.Expire(ctx, c.key(key), expiration).Err())
 }
 
 // Increment는 숫자 값을 원자적으로 증가시킵니다
 func (c *Client) Increment(ctx context.Context, key string) (int64, error) {
-	return c.rdb.Incr(ctx, key).Result()
+	v, err := c.rdb.Incr(ctx, c.key(key)).Result()
+	return v, mapError(err)
 }
 
 // Decrement는 숫자 값을 원자적으로 감소시킵니다
 func (c *Client) Decrement(ctx context.Context, key string) (int64, error) {
-	return c.rdb.Decr(ctx, key).Result()
+	v, err := c.rdb.Decr(ctx, c.key(key)).Result()
+	return v, mapError(err)
 }
 
 // HSet은 해시 필드에 값을 저장합니다
 func (c *Client) HSet(ctx context.Context, key string, values ...interface{}) error {
-	return c.rdb.HSet(ctx, key, values...).Err()
+	return mapError(c.rdb.HSet(ctx, c.key(key), values...).Err())
 }
 
 // HGet은 해시 필드의 값을 조회합니다
 func (c *Client) HGet(ctx context.Context, key, field string) (string, error) {
-	return c.rdb.HGet(ctx, key, field).Result()
+	v, err := c.rdb.HGet(ctx, c.key(key), field).Result()
+	return v, mapError(err)
 }
 
 // HGetAll은 해시의 모든 필드와 값을 조회합니다
 func (c *Client) HGetAll(ctx context.Context, key string) (map[string]string, error) {
-	return c.rdb.HGetAll(ctx, key).Result()
+	v, err := c.rdb.HGetAll(ctx, c.key(key)).Result()
+	return v, mapError(err)
 }
 
 // LPush는 리스트의 왼쪽에 값을 추가합니다
 func (c *Client) LPush(ctx context.Context, key string, values ...interface{}) error {
-	return c.rdb.LPush(ctx, key, values...).Err()
+	return mapError(c.rdb.LPush(ctx, c.key(key), values...).Err())
 }
 
 // RPush는 리스트의 오른쪽에 값을 추가합니다
 func (c *Client) RPush(ctx context.Context, key string, values ...interface{}) error {
-	return c.rdb.RPush(ctx, key, values...).Err()
+	return mapError(c.rdb.RPush(ctx, c.key(key), values...).Err())
 }
 
 // LRange는 리스트의 범위를 조회합니다
 func (c *Client) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
-	return c.rdb.LRange(ctx, key, start, stop).Result()
+	v, err := c.rdb.LRange(ctx, c.key(key), start, stop).Result()
+	return v, mapError(err)
+}
+
+// LLen은 리스트의 길이를 반환합니다
+func (c *Client) LLen(ctx context.Context, key string) (int64, error) {
+	v, err := c.rdb.LLen(ctx, c.key(key)).Result()
+	return v, mapError(err)
+}
+
+// LRem은 리스트에서 value와 같은 요소를 count개 제거하고 제거한 수를 반환합니다
+//
+// count가 0이면 모두, 음수이면 오른쪽부터 제거합니다
+func (c *Client) LRem(ctx context.Context, key string, count int64, value interface{}) (int64, error) {
+	v, err := c.rdb.LRem(ctx, c.key(key), count, value).Result()
+	return v, mapError(err)
+}
+
+// LMove는 source의 srcPos("LEFT" 또는 "RIGHT") 요소를 꺼내 destination의 dstPos에 넣고 반환합니다
+//
+// source가 비어 있으면 ErrNotFound를 반환합니다
+func (c *Client) LMove(ctx context.Context, source, destination, srcPos, dstPos string) (string, error) {
+	v, err := c.rdb.LMove(ctx, c.key(source), c.key(destination), srcPos, dstPos).Result()
+	return v, mapError(err)
+}
+
+// BLMove는 LMove와 같지만 source가 비어 있으면 최대 timeout 동안 기다립니다
+//
+// timeout이 0이면 요소가 들어올 때까지 기다리고, 시간이 지나면 ErrNotFound를 반환합니다
+func (c *Client) BLMove(ctx context.Context, source, destination, srcPos, dstPos string, timeout time.Duration) (string, error) {
+	v, err := c.rdb.BLMove(ctx, c.key(source), c.key(destination), srcPos, dstPos, timeout).Result()
+	return v, mapError(err)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/redis"
@@ -197,3 +198,69 @@ func TestRedisList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"third", "second", "first"}, items)
 }
+
+func TestRedisListMove(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "jobs", "jobs:processing")
+
+	err := client.LPush(ctx, "jobs", "job1", "job2", "job3")
+	assert.NoError(t, err)
+
+	n, err := client.LLen(ctx, "jobs")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// 가장 먼저 넣은 요소를 처리 중 리스트로 이동
+	job, err := client.LMove(ctx, "jobs", "jobs:processing", "RIGHT", "LEFT")
+	assert.NoError(t, err)
+	assert.Equal(t, "job1", job)
+
+	job, err = client.BLMove(ctx, "jobs", "jobs:processing", "RIGHT", "LEFT", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "job2", job)
+
+	items, err := client.LRange(ctx, "jobs:processing", 0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"job2", "job1"}, items)
+
+	// 처리 완료된 요소 제거
+	removed, err := client.LRem(ctx, "jobs:processing", 1, "job1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	// 빈 리스트는 timeout 후 redis.Nil
+	_ = client.Delete(ctx, "jobs")
+	_, err = client.BLMove(ctx, "jobs", "jobs:processing", "RIGHT", "LEFT", 100*time.Millisecond)
+	assert.ErrorIs(t, err, goredis.Nil)
+}
+
+func TestRedisScan(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "scan:a", "scan:b", "scan:c", "other:a")
+
+	for _, key := range []string{"scan:a", "scan:b", "scan:c", "other:a"} {
+		err := client.Set(ctx, key, "1", 0)
+		assert.NoError(t, err)
+	}
+
+	var keys []string
+	for key, err := range client.Scan(ctx, "scan:*", 1) {
+		assert.NoError(t, err)
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"scan:a", "scan:b", "scan:c"}, keys)
+
+	// 순회 중단
+	n := 0
+	for range client.Scan(ctx, "scan:*", 1) {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+}
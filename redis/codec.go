@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec은 Go 값을 Redis에 저장할 바이트로 변환합니다
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec은 encoding/json을 사용하는 Codec입니다
+type JSONCodec struct{}
+
+// Marshal은 값을 JSON으로 인코딩합니다
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal은 JSON을 값으로 디코딩합니다
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// MsgpackCodec은 MessagePack을 사용하는 Codec입니다
+type MsgpackCodec struct{}
+
+// Marshal은 값을 MessagePack으로 인코딩합니다
+func (MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+// Unmarshal은 MessagePack을 값으로 디코딩합니다
+func (MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+// GobCodec은 encoding/gob을 사용하는 Codec입니다
+//
+// 인터페이스 타입 필드를 저장하려면 gob.Register로 구체 타입을 등록해야 합니다
+type GobCodec struct{}
+
+// Marshal은 값을 gob으로 인코딩합니다
+func (GobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal은 gob을 값으로 디코딩합니다
+func (GobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// 압축 Codec이 인코딩 결과 앞에 붙이는 헤더
+const (
+	frameRaw  byte = 0x00
+	frameGzip byte = 0x01
+)
+
+// ErrInvalidFrame은 압축 Codec이 쓰지 않은 데이터를 디코딩하려 할 때 반환됩니다
+var ErrInvalidFrame = errors.New("invalid compressed frame")
+
+// CompressedCodec은 인코딩 결과가 Threshold 바이트 이상이면 gzip으로 압축합니다
+//
+// 압축 여부는 1바이트 헤더로 기록되므로 같은 CompressedCodec으로만 디코딩할 수 있습니다
+type CompressedCodec struct {
+	// Codec은 실제 직렬화에 사용할 Codec입니다
+	Codec Codec
+	// Threshold는 압축을 시작할 최소 크기(바이트)입니다
+	Threshold int
+}
+
+// NewCompressedCodec은 threshold 바이트 이상인 값을 압축하는 Codec을 생성합니다
+func NewCompressedCodec(codec Codec, threshold int) *CompressedCodec {
+	return &CompressedCodec{Codec: codec, Threshold: threshold}
+}
+
+// Marshal은 값을 인코딩하고 필요하면 압축합니다
+func (c *CompressedCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := c.Codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < c.Threshold {
+		return append([]byte{frameRaw}, data...), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(frameGzip)
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal은 필요하면 압축을 해제한 뒤 값을 디코딩합니다
+func (c *CompressedCodec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return ErrInvalidFrame
+	}
+
+	switch data[0] {
+	case frameRaw:
+		return c.Codec.Unmarshal(data[1:], v)
+	case frameGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+
+		raw, err := io.ReadAll(zr)
+		if err != nil {
+			return err
+		}
+		return c.Codec.Unmarshal(raw, v)
+	default:
+		return fmt.Errorf("%w: unknown header 0x%02x", ErrInvalidFrame, data[0])
+	}
+}
@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type profile struct {
+	Name  string   `json:"name" msgpack:"name"`
+	Email string   `json:"email" msgpack:"email"`
+	Tags  []string `json:"tags" msgpack:"tags"`
+	Age   int      `json:"age" msgpack:"age"`
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	codecs := map[string]Codec{
+		"json":    JSONCodec{},
+		"msgpack": MsgpackCodec{},
+		"gob":     GobCodec{},
+		"gzip":    NewCompressedCodec(JSONCodec{}, 0),
+	}
+
+	in := profile{Name: "John", Email: "john@example.com", Tags: []string{"a", "b"}, Age: 30}
+	for name, codec := range codecs {
+		data, err := codec.Marshal(in)
+		require.NoError(t, err, name)
+
+		var out profile
+		require.NoError(t, codec.Unmarshal(data, &out), name)
+		assert.Equal(t, in, out, name)
+	}
+}
+
+func TestCompressedCodecThreshold(t *testing.T) {
+	codec := NewCompressedCodec(JSONCodec{}, 256)
+
+	// 임계값보다 작으면 압축하지 않음
+	small, err := codec.Marshal("short")
+	require.NoError(t, err)
+	assert.Equal(t, frameRaw, small[0])
+	assert.Equal(t, `"short"`, string(small[1:]))
+
+	// 임계값 이상이면 gzip으로 압축
+	long := strings.Repeat("compressible ", 100)
+	large, err := codec.Marshal(long)
+	require.NoError(t, err)
+	assert.Equal(t, frameGzip, large[0])
+	assert.Less(t, len(large), len(long))
+
+	var out string
+	require.NoError(t, codec.Unmarshal(large, &out))
+	assert.Equal(t, long, out)
+
+	// 헤더가 없는 데이터는 거부
+	assert.ErrorIs(t, codec.Unmarshal(nil, &out), ErrInvalidFrame)
+	assert.ErrorIs(t, codec.Unmarshal([]byte(`{"a":1}`), &out), ErrInvalidFrame)
+}
+
+func TestRedisJSONHelpers(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "profile:1", "profile:missing")
+
+	in := profile{Name: "John", Email: "john@example.com", Tags: []string{"admin"}, Age: 30}
+	err := SetJSON(ctx, client, "profile:1", in, time.Minute)
+	require.NoError(t, err)
+
+	// 저장된 값은 일반 JSON 문자열
+	raw, err := client.Get(ctx, "profile:1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"John","email":"john@example.com","tags":["admin"],"age":30}`, raw)
+
+	out, err := GetJSON[profile](ctx, client, "profile:1")
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	// 없는 키
+	_, err = GetJSON[profile](ctx, client, "profile:missing")
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestRedisValueWithCodec(t *testing.T) {
+	ctx := context.Background()
+
+	// 공유 클라이언트의 Codec을 바꾸지 않도록 별도 클라이언트 사용
+	client := NewClient(testEndpoint)
+	defer client.Close()
+
+	in := profile{Name: "Jane", Email: "jane@example.com", Tags: []string{strings.Repeat("x", 2048)}, Age: 25}
+
+	for name, codec := range map[string]Codec{
+		"msgpack":      MsgpackCodec{},
+		"gob":          GobCodec{},
+		"msgpack+gzip": NewCompressedCodec(MsgpackCodec{}, 1024),
+	} {
+		client.SetCodec(codec)
+		key := "codec:" + name
+
+		err := SetValue(ctx, client, key, in, time.Minute)
+		require.NoError(t, err, name)
+
+		out, err := GetValue[profile](ctx, client, key)
+		require.NoError(t, err, name)
+		assert.Equal(t, in, out, name)
+	}
+
+	// 압축된 값은 원본보다 작게 저장됨
+	raw, err := client.Get(ctx, "codec:msgpack+gzip")
+	require.NoError(t, err)
+	assert.Less(t, len(raw), 2048)
+}
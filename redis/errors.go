@@ -0,0 +1,163 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// errors.Is로 비교할 수 있는 에러 종류
+var (
+	// ErrNotFound는 키나 해시 필드가 없을 때 반환됩니다 (go-redis의 redis.Nil)
+	ErrNotFound = errors.New("not found")
+	// ErrConnection은 연결 실패, 연결 끊김 또는 서버가 요청을 받을 수 없는 상태(LOADING 등)입니다
+	ErrConnection = errors.New("connection error")
+	// ErrTimeout은 읽기/쓰기 타임아웃, 커넥션 풀 대기 타임아웃 또는 ctx 데드라인 초과입니다
+	ErrTimeout = errors.New("timeout")
+	// ErrWrongType은 키에 저장된 값의 타입에 맞지 않는 명령을 실행했을 때(WRONGTYPE) 반환됩니다
+	ErrWrongType = errors.New("wrong type")
+	// ErrScript는 Lua 스크립트 실행 중 발생한 에러입니다 (컴파일 에러, redis.error_reply 등)
+	ErrScript = errors.New("script error")
+)
+
+// Error는 종류별로 분류된 Redis 에러입니다
+//
+// errors.Is(err, ErrNotFound)처럼 종류를 비교할 수 있고,
+// 원본 에러도 감싸고 있으므로 errors.Is(err, redis.Nil)도 계속 동작합니다
+type Error struct {
+	// Kind는 ErrNotFound, ErrWrongType 등 에러 종류입니다
+	Kind error
+	// Code는 서버 에러 응답의 접두어입니다 (예: "WRONGTYPE", "ERR", 서버 응답이 아니면 빈 문자열)
+	Code string
+	// Err는 원본 에러입니다
+	Err error
+}
+
+// Error는 에러 메시지를 반환합니다
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+// Is는 errors.Is에서 에러 종류와 비교할 수 있도록 합니다
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap은 원본 에러를 반환합니다
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// mapError는 go-redis 에러를 종류에 따라 분류된 *Error로 변환합니다
+//
+// 분류할 수 없는 에러(ctx 취소, 기타 서버 에러 응답 등)는 그대로 반환합니다
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mapped *Error
+	if errors.As(err, &mapped) {
+		return err
+	}
+
+	if errors.Is(err, redis.Nil) {
+		return &Error{Kind: ErrNotFound, Err: err}
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		code := errorCode(redisErr)
+		kind := replyErrorKind(code)
+		if kind == nil {
+			return err
+		}
+		return &Error{Kind: kind, Code: code, Err: err}
+	}
+
+	if isTimeoutError(err) {
+		return &Error{Kind: ErrTimeout, Err: err}
+	}
+	if isConnectionError(err) {
+		return &Error{Kind: ErrConnection, Err: err}
+	}
+	return err
+}
+
+// mapScriptError는 mapError와 같지만, 분류되지 않은 서버 에러 응답을 ErrScript로 분류합니다
+func mapScriptError(err error) error {
+	err = mapError(err)
+
+	var mapped *Error
+	if errors.As(err, &mapped) {
+		return err
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		return &Error{Kind: ErrScript, Code: errorCode(redisErr), Err: err}
+	}
+	return err
+}
+
+// errorCode는 서버 에러 응답의 첫 단어를 반환합니다
+func errorCode(err redis.Error) string {
+	code, _, _ := strings.Cut(err.Error(), " ")
+	return code
+}
+
+// replyErrorKind는 서버 에러 응답 접두어에 해당하는 에러 종류를 반환합니다
+func replyErrorKind(code string) error {
+	switch code {
+	case "WRONGTYPE":
+		return ErrWrongType
+	case "NOSCRIPT":
+		return ErrScript
+	case "LOADING", "MASTERDOWN", "CLUSTERDOWN":
+		// 서버가 시작 중이거나 마스터와 연결이 끊겨 요청을 받을 수 없음
+		return ErrConnection
+	}
+	return nil
+}
+
+// isTimeoutError는 네트워크 타임아웃 또는 데드라인 초과 에러인지 확인합니다
+func isTimeoutError(err error) bool {
+	if errors.Is(err, redis.ErrPoolTimeout) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isConnectionError는 네트워크 또는 클라이언트 수준의 연결 에러인지 확인합니다
+func isConnectionError(err error) bool {
+	if errors.Is(err, redis.ErrClosed) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isPayloadError는 연결은 정상이지만 받은 Pub/Sub 메시지의 payload를 해석하지 못한 에러인지 확인합니다
+//
+// go-redis는 문자열이나 배열이 아닌 payload(RESP2 FLUSHALL/FLUSHDB 무효화 메시지의 nil 등)를
+// 메시지 대신 이 에러로 반환합니다
+func isPayloadError(err error) bool {
+	return strings.HasPrefix(err.Error(), "redis: unsupported pubsub message payload")
+}
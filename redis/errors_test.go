@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replyError는 서버 에러 응답을 흉내 내는 goredis.Error입니다
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+
+func (replyError) RedisError() {}
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+		code string
+	}{
+		{"nil reply", goredis.Nil, ErrNotFound, ""},
+		{"wrong type", replyError("WRONGTYPE Operation against a key holding the wrong kind of value"), ErrWrongType, "WRONGTYPE"},
+		{"no script", replyError("NOSCRIPT No matching script"), ErrScript, "NOSCRIPT"},
+		{"loading", replyError("LOADING Redis is loading the dataset in memory"), ErrConnection, "LOADING"},
+		{"closed", goredis.ErrClosed, ErrConnection, ""},
+		{"eof", io.EOF, ErrConnection, ""},
+		{"dial", &net.OpError{Op: "dial", Err: errors.New("refused")}, ErrConnection, ""},
+		{"pool timeout", goredis.ErrPoolTimeout, ErrTimeout, ""},
+		{"deadline", context.DeadlineExceeded, ErrTimeout, ""},
+	}
+
+	for _, tt := range tests {
+		err := mapError(fmt.Errorf("wrapped: %w", tt.err))
+		assert.ErrorIs(t, err, tt.kind, tt.name)
+
+		var redisErr *Error
+		require.True(t, errors.As(err, &redisErr), tt.name)
+		assert.Equal(t, tt.code, redisErr.Code, tt.name)
+
+		// 원본 에러도 그대로 비교할 수 있어야 함
+		assert.ErrorIs(t, err, tt.err, tt.name)
+	}
+
+	// 분류할 수 없는 에러는 그대로 반환
+	plain := errors.New("plain")
+	assert.Same(t, plain, mapError(plain))
+	syntax := replyError("ERR syntax error")
+	assert.Equal(t, syntax, mapError(syntax))
+	assert.ErrorIs(t, mapError(context.Canceled), context.Canceled)
+	assert.NotErrorIs(t, mapError(context.Canceled), ErrTimeout)
+	assert.NoError(t, mapError(nil))
+
+	// 스크립트 실행 중의 서버 에러 응답은 ErrScript
+	assert.ErrorIs(t, mapScriptError(syntax), ErrScript)
+	assert.ErrorIs(t, mapScriptError(replyError("WRONGTYPE Operation")), ErrWrongType)
+
+	// 이미 분류된 에러는 다시 감싸지 않음
+	err := mapError(goredis.Nil)
+	assert.Same(t, err, mapError(err))
+}
+
+func TestRedisErrorTaxonomy(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	type account struct {
+		Name string `redis:"name"`
+	}
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "errors:missing", "errors:string", "errors:list")
+	require.NoError(t, client.Set(ctx, "errors:string", "value", 0))
+
+	// 없는 키와 필드는 ErrNotFound
+	_, err := client.Get(ctx, "errors:missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, goredis.Nil)
+
+	_, err = client.HGet(ctx, "errors:missing", "field")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = GetValue[int](ctx, client, "errors:missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.ErrorIs(t, client.HGetAllStruct(ctx, "errors:missing", &account{}), ErrNotFound)
+
+	_, err = client.LMove(ctx, "errors:list", "errors:list", "RIGHT", "LEFT")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	b := client.Pipeline()
+	missing := b.Get("errors:missing")
+	require.NoError(t, b.Exec(ctx))
+	assert.ErrorIs(t, missing.Err(), ErrNotFound)
+
+	// 값의 타입에 맞지 않는 명령은 ErrWrongType
+	_, err = client.HGet(ctx, "errors:string", "field")
+	assert.ErrorIs(t, err, ErrWrongType)
+	var redisErr *Error
+	require.True(t, errors.As(err, &redisErr))
+	assert.Equal(t, "WRONGTYPE", redisErr.Code)
+
+	assert.ErrorIs(t, client.LPush(ctx, "errors:string", "x"), ErrWrongType)
+	_, err = client.LRange(ctx, "errors:string", 0, -1)
+	assert.ErrorIs(t, err, ErrWrongType)
+
+	// 스크립트가 반환한 에러는 ErrScript
+	failing := NewScript(`return redis.error_reply('quota exceeded')`)
+	_, err = client.RunScript(ctx, failing, nil)
+	assert.ErrorIs(t, err, ErrScript)
+	assert.ErrorContains(t, err, "quota exceeded")
+
+	invalid := NewScript(`this is not lua`)
+	_, err = client.RunScript(ctx, invalid, nil)
+	assert.ErrorIs(t, err, ErrScript)
+}
+
+func TestRedisConnectionError(t *testing.T) {
+	// 아무것도 수신하지 않는 포트로 연결
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := NewClient(addr)
+	_, err = client.Get(context.Background(), "key")
+	assert.ErrorIs(t, err, ErrConnection)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	// 닫힌 클라이언트
+	require.NoError(t, client.Close())
+	assert.ErrorIs(t, client.Set(context.Background(), "key", "value", 0), ErrConnection)
+}
+
+func TestRedisTimeoutError(t *testing.T) {
+	// 연결은 받지만 응답하지 않는 서버
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	client := NewClient(listener.Addr().String())
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.NotErrorIs(t, err, ErrConnection)
+}
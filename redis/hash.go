@@ -0,0 +1,239 @@
+package redis
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotStruct는 HSetStruct/HGetAllStruct에 구조체가 아닌 값을 전달했을 때 반환됩니다
+var ErrNotStruct = errors.New("value must be a struct or a pointer to struct")
+
+// hashField는 `redis` 태그가 붙은 구조체 필드입니다
+type hashField struct {
+	name      string
+	index     int
+	omitEmpty bool
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// HSetStruct는 구조체의 `redis:"field"` 태그 필드를 해시 필드로 저장합니다
+//
+// 문자열, 숫자, bool, []byte, time.Time, encoding.TextMarshaler와 encoding.TextUnmarshaler를
+// 함께 구현한 타입(포인터 리시버 포함)은 문자열로 저장하고,
+// 그 밖의 타입(슬라이스, 맵, 중첩 구조체 등)은 클라이언트의 Codec으로 인코딩합니다.
+// `redis:"field,omitempty"`는 zero 값인 필드를 건너뜁니다
+func (c *Client) HSetStruct(ctx context.Context, key string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	var values []interface{}
+	for _, f := range hashFields(rv.Type()) {
+		fv := rv.Field(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+
+		encoded, err := c.encodeField(fv)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+		values = append(values, f.name, encoded)
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	return mapError(c.rdb.HSet(ctx, c.key(key), values...).Err())
+}
+
+// HGetAllStruct는 해시의 필드를 dst 구조체의 `redis` 태그 필드로 읽어옵니다
+//
+// 해시가 없으면 ErrNotFound를 반환하고, 구조체에 없는 해시 필드는 무시합니다
+func (c *Client) HGetAllStruct(ctx context.Context, key string, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	rv = rv.Elem()
+
+	hash, err := c.rdb.HGetAll(ctx, c.key(key)).Result()
+	if err != nil {
+		return mapError(err)
+	}
+	if len(hash) == 0 {
+		return mapError(redis.Nil)
+	}
+
+	for _, f := range hashFields(rv.Type()) {
+		raw, ok := hash[f.name]
+		if !ok {
+			continue
+		}
+
+		fv := rv.Field(f.index)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+
+		if err := c.decodeField(raw, fv); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// hashFields는 `redis` 태그가 있는 내보낸 필드 목록을 반환합니다
+func hashFields(t reflect.Type) []hashField {
+	var fields []hashField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("redis")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			continue
+		}
+		fields = append(fields, hashField{
+			name:      name,
+			index:     i,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+	return fields
+}
+
+// isTextField는 필드를 MarshalText/UnmarshalText로 저장하고 읽을지 확인합니다
+//
+// 인코딩과 디코딩이 같은 방식을 쓰도록 포인터 타입이 두 인터페이스를 모두 구현할 때만 텍스트로 다룹니다
+func isTextField(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(textMarshalerType) && pt.Implements(textUnmarshalerType)
+}
+
+// encodeField는 필드 값을 해시에 저장할 문자열로 변환합니다
+func (c *Client) encodeField(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+	if isTextField(v.Type()) {
+		if !v.CanAddr() {
+			addressable := reflect.New(v.Type()).Elem()
+			addressable.Set(v)
+			v = addressable
+		}
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), nil
+		}
+	}
+
+	data, err := c.codec.Marshal(v.Interface())
+	return string(data), err
+}
+
+// decodeField는 해시 값을 필드 타입으로 변환해 저장합니다
+func (c *Client) decodeField(raw string, v reflect.Value) error {
+	if v.Type() == timeType {
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if isTextField(v.Type()) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(raw))
+			return nil
+		}
+	}
+
+	return c.codec.Unmarshal([]byte(raw), v.Addr().Interface())
+}
@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type session struct {
+	UserID    int64             `redis:"user_id"`
+	Name      string            `redis:"name"`
+	Admin     bool              `redis:"admin"`
+	Score     float64           `redis:"score"`
+	LoginAt   time.Time         `redis:"login_at"`
+	Addr      netip.Addr        `redis:"addr"`
+	Roles     []string          `redis:"roles"`
+	Meta      map[string]string `redis:"meta"`
+	Nickname  *string           `redis:"nickname"`
+	Note      string            `redis:"note,omitempty"`
+	Ignored   string            `redis:"-"`
+	untracked string
+}
+
+// version은 포인터 리시버로만 텍스트 인코딩을 구현한 타입입니다
+type version struct {
+	major, minor int
+}
+
+func (v *version) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "v%d.%d", v.major, v.minor), nil
+}
+
+func (v *version) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "v%d.%d", &v.major, &v.minor)
+	return err
+}
+
+type release struct {
+	Name    string  `redis:"name"`
+	Version version `redis:"version"`
+}
+
+func TestRedisHashStruct(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "session:1", "session:missing")
+
+	nickname := "johnny"
+	in := session{
+		UserID:    42,
+		Name:      "John",
+		Admin:     true,
+		Score:     98.5,
+		LoginAt:   time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		Addr:      netip.MustParseAddr("10.0.0.1"),
+		Roles:     []string{"admin", "editor"},
+		Meta:      map[string]string{"theme": "dark"},
+		Nickname:  &nickname,
+		Ignored:   "ignored",
+		untracked: "untracked",
+	}
+
+	err := client.HSetStruct(ctx, "session:1", &in)
+	require.NoError(t, err)
+
+	// 스칼라 필드는 문자열, 복합 필드는 Codec(JSON)으로 저장
+	all, err := client.HGetAll(ctx, "session:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"user_id":  "42",
+		"name":     "John",
+		"admin":    "true",
+		"score":    "98.5",
+		"login_at": "2024-05-01T12:30:00Z",
+		"addr":     "10.0.0.1",
+		"roles":    `["admin","editor"]`,
+		"meta":     `{"theme":"dark"}`,
+		"nickname": "johnny",
+	}, all)
+
+	var out session
+	err = client.HGetAllStruct(ctx, "session:1", &out)
+	require.NoError(t, err)
+
+	in.Ignored = ""
+	in.untracked = ""
+	assert.Equal(t, in, out)
+
+	// 없는 해시
+	err = client.HGetAllStruct(ctx, "session:missing", &out)
+	assert.ErrorIs(t, err, redis.Nil)
+
+	// 구조체가 아닌 값
+	assert.ErrorIs(t, client.HSetStruct(ctx, "session:1", "text"), ErrNotStruct)
+	assert.ErrorIs(t, client.HGetAllStruct(ctx, "session:1", out), ErrNotStruct)
+}
+
+func TestRedisHashStructPointerTextMarshaler(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	_ = client.Delete(ctx, "release:1")
+
+	// 포인터가 아닌 값으로 전달해도 포인터 리시버 MarshalText로 저장
+	in := release{Name: "stable", Version: version{major: 1, minor: 2}}
+	require.NoError(t, client.HSetStruct(ctx, "release:1", in))
+
+	all, err := client.HGetAll(ctx, "release:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "stable", "version": "v1.2"}, all)
+
+	var out release
+	require.NoError(t, client.HGetAllStruct(ctx, "release:1", &out))
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,292 @@
+package redis
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// 락 기본 설정값
+const (
+	DefaultLockTTL            = 10 * time.Second
+	DefaultLockInitialBackoff = 10 * time.Millisecond
+	DefaultLockMaxBackoff     = 500 * time.Millisecond
+)
+
+var (
+	// ErrLockNotAcquired는 다른 소유자가 락을 가지고 있어 획득하지 못했을 때 반환됩니다
+	ErrLockNotAcquired = errors.New("lock not acquired")
+	// ErrLockNotHeld는 만료 등으로 더 이상 락을 소유하지 않을 때 반환됩니다
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// acquireScript는 락을 SET NX PX로 획득하고 성공하면 펜싱 토큰을 증가시켜 반환합니다
+var acquireScript = NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return redis.call('INCR', KEYS[2])
+end
+return 0
+`)
+
+// releaseScript는 토큰이 일치할 때만 락을 삭제합니다
+var releaseScript = NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// extendScript는 토큰이 일치할 때만 락의 만료 시간을 연장합니다
+var extendScript = NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// lockConfig는 락 획득 설정입니다
+type lockConfig struct {
+	ttl            time.Duration
+	autoExtend     bool
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// LockOption은 락 획득 동작을 설정합니다
+type LockOption func(*lockConfig)
+
+// WithLockTTL은 락의 만료 시간(lease)을 설정합니다 (기본값 DefaultLockTTL)
+func WithLockTTL(ttl time.Duration) LockOption {
+	return func(c *lockConfig) {
+		c.ttl = ttl
+	}
+}
+
+// WithoutAutoExtend는 락을 가지고 있는 동안 만료 시간을 자동으로 연장하지 않도록 합니다
+func WithoutAutoExtend() LockOption {
+	return func(c *lockConfig) {
+		c.autoExtend = false
+	}
+}
+
+// WithLockBackoff는 Lock이 재시도할 때의 대기 시간 범위를 설정합니다
+func WithLockBackoff(initial, max time.Duration) LockOption {
+	return func(c *lockConfig) {
+		c.initialBackoff = initial
+		c.maxBackoff = max
+	}
+}
+
+// Lock은 Redis에 저장된 분산 락입니다
+//
+// 락 값으로 무작위 토큰을 저장하므로 토큰이 일치하는 소유자만 연장하거나 해제할 수 있습니다
+type Lock struct {
+	client *Client
+	name   string
+	key    string
+	token  string
+	fence  int64
+	ttl    time.Duration
+
+	stop     chan struct{}
+	stopped  chan struct{}
+	lost     chan struct{}
+	stopOnce sync.Once
+}
+
+// TryLock은 락을 한 번 시도해 획득합니다 (이미 잠겨 있으면 ErrLockNotAcquired)
+//
+// 락은 "{key}", 펜싱 토큰은 "{key}:fence" 키에 저장되므로 Cluster에서도 두 키가 같은 슬롯에 놓입니다
+func (c *Client) TryLock(ctx context.Context, key string, opts ...LockOption) (*Lock, error) {
+	cfg := newLockConfig(opts)
+	return c.tryLock(ctx, key, cfg)
+}
+
+// Lock은 락을 획득할 때까지 지수 백오프로 재시도합니다
+//
+// ctx가 취소되면 ctx.Err()를 반환합니다
+func (c *Client) Lock(ctx context.Context, key string, opts ...LockOption) (*Lock, error) {
+	cfg := newLockConfig(opts)
+
+	for attempt := 1; ; attempt++ {
+		lock, err := c.tryLock(ctx, key, cfg)
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return lock, err
+		}
+
+		timer := time.NewTimer(backoff(cfg.initialBackoff, cfg.maxBackoff, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func newLockConfig(opts []LockOption) *lockConfig {
+	cfg := &lockConfig{
+		ttl:            DefaultLockTTL,
+		autoExtend:     true,
+		initialBackoff: DefaultLockInitialBackoff,
+		maxBackoff:     DefaultLockMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// backoff는 attempt번째 재시도 전 대기 시간을 지터를 적용해 계산합니다
+func backoff(initial, maxDelay time.Duration, attempt int) time.Duration {
+	delay := initial << min(attempt-1, 30)
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// 동시에 대기하는 클라이언트가 한꺼번에 재시도하지 않도록 [delay/2, delay] 범위로 분산
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+func (c *Client) tryLock(ctx context.Context, key string, cfg *lockConfig) (*Lock, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	name := key
+	key, fenceKey := lockKeys(key)
+	fence, err := RunScriptValue[int64](ctx, c, acquireScript, []string{key, fenceKey}, token, cfg.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if fence == 0 {
+		return nil, ErrLockNotAcquired
+	}
+
+	lock := &Lock{
+		client:  c,
+		name:    name,
+		key:     key,
+		token:   token,
+		fence:   fence,
+		ttl:     cfg.ttl,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		lost:    make(chan struct{}),
+	}
+
+	if cfg.autoExtend {
+		go lock.keepAlive()
+	} else {
+		close(lock.stopped)
+	}
+	return lock, nil
+}
+
+// lockKeys는 락 키와 펜싱 토큰 키를 반환합니다
+//
+// 스크립트가 두 키를 함께 다루므로 Cluster에서 같은 슬롯에 놓이도록 key를 해시 태그로 감쌉니다
+func lockKeys(key string) (string, string) {
+	tagged := "{" + key + "}"
+	return tagged, tagged + ":fence"
+}
+
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Key는 TryLock/Lock에 전달한 락 이름을 반환합니다 (네임스페이스 접두사와 해시 태그 제외)
+func (l *Lock) Key() string {
+	return l.name
+}
+
+// Token은 이 소유자를 식별하는 토큰을 반환합니다
+func (l *Lock) Token() string {
+	return l.token
+}
+
+// Fence는 획득할 때마다 증가하는 펜싱 토큰을 반환합니다
+//
+// 보호 대상 저장소에 함께 기록하면 만료 후 늦게 도착한 이전 소유자의 쓰기를 거부할 수 있습니다
+func (l *Lock) Fence() int64 {
+	return l.fence
+}
+
+// Lost는 자동 연장에 실패해 락을 잃었을 때 닫히는 채널을 반환합니다
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Extend는 락의 만료 시간을 ttl로 갱신합니다 (소유하지 않으면 ErrLockNotHeld)
+func (l *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	extended, err := RunScriptValue[bool](ctx, l.client, extendScript, []string{l.key}, l.token, ttl)
+	if err != nil {
+		return err
+	}
+	if !extended {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Unlock은 자동 연장을 멈추고 락을 해제합니다 (이미 만료되었으면 ErrLockNotHeld)
+//
+// 토큰이 일치할 때만 삭제하므로 만료 후 다른 소유자가 획득한 락은 지우지 않습니다
+func (l *Lock) Unlock(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.stopped
+
+	deleted, err := RunScriptValue[bool](ctx, l.client, releaseScript, []string{l.key}, l.token)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// keepAlive는 TTL의 1/3마다 락을 연장합니다
+//
+// 락을 잃었거나 TTL 동안 연장에 계속 실패하면 Lost 채널을 닫고 종료합니다
+func (l *Lock) keepAlive() {
+	defer close(l.stopped)
+
+	interval := max(l.ttl/3, time.Millisecond)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastExtended := time.Now()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := l.Extend(ctx, l.ttl)
+		cancel()
+
+		if err == nil {
+			lastExtended = time.Now()
+			continue
+		}
+		if errors.Is(err, ErrLockNotHeld) || time.Since(lastExtended) >= l.ttl {
+			close(l.lost)
+			return
+		}
+	}
+}
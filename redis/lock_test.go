@@ -0,0 +1,186 @@
+package redis
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisTryLock(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "{lock:order}", "{lock:order}:fence")
+
+	lock, err := client.TryLock(ctx, "lock:order", WithLockTTL(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "lock:order", lock.Key())
+	assert.Equal(t, int64(1), lock.Fence())
+
+	// 락 값은 소유자 토큰
+	value, err := client.Get(ctx, "{lock:order}")
+	require.NoError(t, err)
+	assert.Equal(t, lock.Token(), value)
+
+	// 이미 잠긴 락은 획득 불가
+	_, err = client.TryLock(ctx, "lock:order")
+	assert.ErrorIs(t, err, ErrLockNotAcquired)
+
+	// 해제 후 다시 획득하면 펜싱 토큰 증가
+	require.NoError(t, lock.Unlock(ctx))
+	assert.ErrorIs(t, lock.Unlock(ctx), ErrLockNotHeld)
+
+	next, err := client.TryLock(ctx, "lock:order")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), next.Fence())
+	require.NoError(t, next.Unlock(ctx))
+}
+
+func TestRedisLockExpiredOwner(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "{lock:expired}", "{lock:expired}:fence")
+
+	// 자동 연장 없이 만료된 락
+	stale, err := client.TryLock(ctx, "lock:expired", WithLockTTL(100*time.Millisecond), WithoutAutoExtend())
+	require.NoError(t, err)
+	time.Sleep(200 * time.Millisecond)
+
+	owner, err := client.TryLock(ctx, "lock:expired", WithLockTTL(5*time.Second))
+	require.NoError(t, err)
+	assert.Greater(t, owner.Fence(), stale.Fence())
+
+	// 이전 소유자는 연장하거나 해제할 수 없고, 새 소유자의 락도 지우지 못함
+	assert.ErrorIs(t, stale.Extend(ctx, time.Second), ErrLockNotHeld)
+	assert.ErrorIs(t, stale.Unlock(ctx), ErrLockNotHeld)
+
+	value, err := client.Get(ctx, "{lock:expired}")
+	require.NoError(t, err)
+	assert.Equal(t, owner.Token(), value)
+
+	require.NoError(t, owner.Unlock(ctx))
+}
+
+func TestRedisLockAutoExtend(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "{lock:lease}", "{lock:lease}:fence")
+
+	// TTL보다 오래 가지고 있어도 자동 연장으로 유지됨
+	lock, err := client.TryLock(ctx, "lock:lease", WithLockTTL(300*time.Millisecond))
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	_, err = client.TryLock(ctx, "lock:lease")
+	assert.ErrorIs(t, err, ErrLockNotAcquired)
+
+	select {
+	case <-lock.Lost():
+		t.Fatal("lock should still be held")
+	default:
+	}
+
+	// 락이 외부에서 삭제되면 Lost 채널이 닫힘
+	require.NoError(t, client.Delete(ctx, "{lock:lease}"))
+	select {
+	case <-lock.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lost lock was not detected")
+	}
+	assert.ErrorIs(t, lock.Unlock(ctx), ErrLockNotHeld)
+}
+
+func TestRedisLockBlockingAcquire(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "{lock:blocking}", "{lock:blocking}:fence")
+
+	held, err := client.TryLock(ctx, "lock:blocking")
+	require.NoError(t, err)
+
+	// 락이 해제되지 않으면 ctx 만료까지 대기
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	_, err = client.Lock(timeoutCtx, "lock:blocking")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// 대기 중 해제되면 획득
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = held.Unlock(ctx)
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	lock, err := client.Lock(waitCtx, "lock:blocking", WithLockBackoff(5*time.Millisecond, 50*time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock(ctx))
+}
+
+func TestRedisLockContention(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "{lock:contention}", "{lock:contention}:fence")
+
+	const workers = 20
+	var (
+		holders atomic.Int32
+		counter int
+		mu      sync.Mutex
+		fences  []int64
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lockCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			defer cancel()
+
+			lock, err := client.Lock(lockCtx, "lock:contention", WithLockTTL(2*time.Second))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// 임계 구역에는 한 번에 하나의 소유자만 진입
+			assert.Equal(t, int32(1), holders.Add(1))
+			value := counter
+			time.Sleep(5 * time.Millisecond)
+			counter = value + 1
+			holders.Add(-1)
+
+			mu.Lock()
+			fences = append(fences, lock.Fence())
+			mu.Unlock()
+
+			assert.NoError(t, lock.Unlock(ctx))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, workers, counter)
+
+	// 펜싱 토큰은 획득마다 고유하게 증가
+	require.Len(t, fences, workers)
+	sort.Slice(fences, func(i, j int) bool { return fences[i] < fences[j] })
+	for i, fence := range fences {
+		assert.Equal(t, int64(i+1), fence)
+	}
+}
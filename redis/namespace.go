@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// namespaceDeleteBatch는 DeleteNamespace가 한 번의 파이프라인으로 삭제하는 키 수입니다
+const namespaceDeleteBatch = 500
+
+// ErrNoNamespace는 네임스페이스가 없는 클라이언트에서 DeleteNamespace를 호출했을 때 반환됩니다
+var ErrNoNamespace = errors.New("client has no namespace")
+
+// Namespace는 모든 키 앞에 "name:"을 붙이는 클라이언트 뷰를 반환합니다
+//
+// 뷰는 원본 클라이언트의 연결과 Codec을 공유하며, 뷰에서 다시 Namespace를 호출하면
+// "tenant:orders:"처럼 접두사가 이어집니다. 키를 받는 모든 메서드(여러 키를 받는
+// Delete/Exists, 스크립트, 락, 스트림, 파이프라인, Pub/Sub 채널 포함)에 접두사가
+// 적용되고, Scan과 Pub/Sub 메시지는 접두사를 뗀 이름을 반환합니다.
+// 뷰의 Close는 아무것도 하지 않으므로 연결은 원본 클라이언트로 종료해야 합니다
+func (c *Client) Namespace(name string) *Client {
+	view := *c
+	view.prefix = c.prefix + name + ":"
+	view.view = true
+	return &view
+}
+
+// Prefix는 키 앞에 붙는 접두사를 반환합니다 (네임스페이스가 없으면 빈 문자열)
+func (c *Client) Prefix() string {
+	return c.prefix
+}
+
+// DeleteNamespace는 네임스페이스 안의 모든 키를 삭제하고 삭제한 키 수를 반환합니다
+//
+// KEYS 대신 SCAN으로 키를 찾아 나누어 UNLINK하므로 서버를 오래 막지 않습니다.
+// 순회 중에 추가된 키는 삭제되지 않을 수 있습니다.
+// 원본 클라이언트에서 호출하면 전체 키를 지우지 않도록 ErrNoNamespace를 반환합니다
+func (c *Client) DeleteNamespace(ctx context.Context) (int64, error) {
+	if c.prefix == "" {
+		return 0, ErrNoNamespace
+	}
+
+	var (
+		deleted int64
+		batch   []string
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// 키마다 따로 UNLINK해야 Cluster에서 슬롯이 달라도 실행됨
+		cmds, err := c.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range batch {
+				pipe.Unlink(ctx, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return mapError(err)
+		}
+		for _, cmd := range cmds {
+			deleted += cmd.(*redis.IntCmd).Val()
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for key, err := range c.scan(ctx, escapeGlob(c.prefix)+"*", namespaceDeleteBatch) {
+		if err != nil {
+			return deleted, err
+		}
+		batch = append(batch, key)
+		if len(batch) >= namespaceDeleteBatch {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return deleted, err
+	}
+	return deleted, nil
+}
+
+// key는 네임스페이스 접두사를 붙인 키를 반환합니다
+func (c *Client) key(key string) string {
+	return c.prefix + key
+}
+
+// keys는 각 키에 네임스페이스 접두사를 붙인 새 슬라이스를 반환합니다
+func (c *Client) keys(keys []string) []string {
+	if c.prefix == "" {
+		return keys
+	}
+	out := make([]string, len(keys))
+	for i, key := range keys {
+		out[i] = c.prefix + key
+	}
+	return out
+}
+
+// trimKey는 키에서 네임스페이스 접두사를 뗍니다
+func (c *Client) trimKey(key string) string {
+	return strings.TrimPrefix(key, c.prefix)
+}
+
+// escapeGlob은 SCAN/PSUBSCRIBE 패턴에서 s가 문자 그대로 일치하도록 glob 문자를 이스케이프합니다
+func escapeGlob(s string) string {
+	if !strings.ContainsAny(s, `*?[]\`) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisNamespace(t *testing.T) {
+	ctx := context.Background()
+	tenantA := testClient.Namespace("tenant-a")
+	tenantB := testClient.Namespace("tenant-b")
+
+	// 테스트 전 키 정리
+	_, _ = tenantA.DeleteNamespace(ctx)
+	_, _ = tenantB.DeleteNamespace(ctx)
+
+	// 같은 키라도 네임스페이스마다 따로 저장
+	require.NoError(t, tenantA.Set(ctx, "config", "a", time.Minute))
+	require.NoError(t, tenantB.Set(ctx, "config", "b", time.Minute))
+
+	value, err := tenantA.Get(ctx, "config")
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+
+	value, err = testClient.Get(ctx, "tenant-b:config")
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+
+	// 여러 키를 받는 명령도 모든 키에 접두사 적용
+	require.NoError(t, tenantA.Set(ctx, "other", "x", time.Minute))
+	n, err := tenantA.Exists(ctx, "config", "other", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	require.NoError(t, tenantA.Delete(ctx, "config", "other"))
+	n, err = testClient.Exists(ctx, "tenant-a:config", "tenant-b:config")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// 중첩된 네임스페이스
+	orders := tenantA.Namespace("orders")
+	assert.Equal(t, "tenant-a:orders:", orders.Prefix())
+	require.NoError(t, SetValue(ctx, orders, "1", map[string]int{"qty": 2}, time.Minute))
+	got, err := GetValue[map[string]int](ctx, testClient, "tenant-a:orders:1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got["qty"])
+
+	// 뷰를 닫아도 연결은 유지
+	require.NoError(t, tenantA.Close())
+	require.NoError(t, testClient.Ping(ctx))
+}
+
+func TestRedisNamespaceScanAndDelete(t *testing.T) {
+	ctx := context.Background()
+	// glob 문자가 들어간 네임스페이스도 문자 그대로 일치
+	ns := testClient.Namespace("scan[1]")
+	other := testClient.Namespace("scan1")
+
+	_, _ = ns.DeleteNamespace(ctx)
+	_, _ = other.DeleteNamespace(ctx)
+
+	for i := 0; i < 1200; i++ {
+		require.NoError(t, ns.Set(ctx, fmt.Sprintf("item:%d", i), i, time.Minute))
+	}
+	require.NoError(t, ns.Set(ctx, "meta", "m", time.Minute))
+	require.NoError(t, other.Set(ctx, "item:1", "keep", time.Minute))
+
+	// Scan은 접두사를 뗀 키를 반환
+	var keys []string
+	for key, err := range ns.Scan(ctx, "item:1?", 100) {
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	assert.Equal(t, []string{"item:10", "item:11", "item:12", "item:13", "item:14", "item:15", "item:16", "item:17", "item:18", "item:19"}, keys)
+
+	// 네임스페이스 전체 삭제 (여러 배치로 나누어 삭제)
+	deleted, err := ns.DeleteNamespace(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1201), deleted)
+
+	for key, err := range ns.Scan(ctx, "*", 100) {
+		require.NoError(t, err)
+		t.Fatalf("unexpected key %s", key)
+	}
+
+	// 다른 네임스페이스는 그대로
+	value, err := other.Get(ctx, "item:1")
+	require.NoError(t, err)
+	assert.Equal(t, "keep", value)
+
+	// 원본 클라이언트에서는 전체 삭제를 거부
+	_, err = testClient.DeleteNamespace(ctx)
+	assert.ErrorIs(t, err, ErrNoNamespace)
+}
+
+func TestRedisNamespaceFeatures(t *testing.T) {
+	ctx := context.Background()
+	ns := testClient.Namespace("features")
+	_, _ = ns.DeleteNamespace(ctx)
+
+	// 락
+	lock, err := ns.TryLock(ctx, "job", WithLockTTL(time.Second), WithoutAutoExtend())
+	require.NoError(t, err)
+	assert.Equal(t, "job", lock.Key())
+	n, err := testClient.Exists(ctx, "features:{job}", "features:{job}:fence")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	require.NoError(t, lock.Unlock(ctx))
+
+	// 파이프라인과 Watch
+	b := ns.Pipeline()
+	b.Set("counter", 1, time.Minute)
+	exists := b.Exists("counter", "missing")
+	require.NoError(t, b.Exec(ctx))
+	assert.Equal(t, int64(1), exists.Val())
+
+	err = ns.Watch(ctx, []string{"counter"}, func(tx *Tx) error {
+		v, err := TxGetValue[int](ctx, tx, "counter")
+		if err != nil {
+			return err
+		}
+		b := tx.Multi()
+		BatchSetValue(b, "counter", v+1, time.Minute)
+		return b.Exec(ctx)
+	})
+	require.NoError(t, err)
+	value, err := testClient.Get(ctx, "features:counter")
+	require.NoError(t, err)
+	assert.Equal(t, "2", value)
+
+	// 스크립트
+	script := NewScript(`return redis.call('GET', KEYS[1])`)
+	v, err := ns.RunScript(ctx, script, []string{"counter"})
+	require.NoError(t, err)
+	assert.Equal(t, "2", v)
+
+	// Pub/Sub 채널과 패턴
+	sub, err := ns.Subscribe(ctx, []string{"events"})
+	require.NoError(t, err)
+	defer sub.Close()
+	psub, err := ns.PSubscribe(ctx, []string{"events*"})
+	require.NoError(t, err)
+	defer psub.Close()
+
+	received, err := testClient.Publish(ctx, "features:events", "raw")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), received)
+
+	msg := receive(t, sub)
+	assert.Equal(t, "events", msg.Channel)
+	assert.Equal(t, "raw", msg.Payload)
+
+	msg = receive(t, psub)
+	assert.Equal(t, "events", msg.Channel)
+	assert.Equal(t, "events*", msg.Pattern)
+
+	// 다른 네임스페이스의 같은 채널은 받지 않음
+	received, err = testClient.Namespace("elsewhere").Publish(ctx, "events", "ignored")
+	require.NoError(t, err)
+	assert.Zero(t, received)
+}
@@ -0,0 +1,531 @@
+package redis
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/redis/go-redis/v9/push"
+)
+
+// 근접 캐시 기본 설정값
+const (
+	DefaultNearCacheSize = 10000
+	DefaultNearCacheTTL  = 30 * time.Second
+)
+
+// invalidateChannel은 RESP2에서 무효화 메시지를 받는 채널입니다
+const invalidateChannel = "__redis__:invalidate"
+
+// NearCacheStats는 근접 캐시 통계입니다
+type NearCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Evictions는 용량을 넘어 LRU로 제거된 항목 수입니다
+	Evictions uint64
+	// Invalidations는 무효화 메시지, Invalidate 또는 무효화 연결이 끊겨 제거된 항목 수입니다
+	Invalidations uint64
+	// Size는 현재 로컬에 저장된 항목 수입니다
+	Size int
+}
+
+// nearCacheConfig는 NearCache 설정입니다
+type nearCacheConfig struct {
+	size     int
+	ttl      time.Duration
+	tracking bool
+	// protocol은 무효화 연결의 RESP 버전입니다 (2이면 Pub/Sub 메시지로 알림을 받음)
+	protocol int
+	onError  func(error)
+}
+
+// NearCacheOption은 근접 캐시 동작을 설정합니다
+type NearCacheOption func(*nearCacheConfig)
+
+// WithNearCacheSize는 로컬에 저장할 최대 항목 수를 설정합니다 (기본값 DefaultNearCacheSize)
+func WithNearCacheSize(n int) NearCacheOption {
+	return func(c *nearCacheConfig) {
+		c.size = n
+	}
+}
+
+// WithNearCacheTTL은 로컬 항목의 최대 보관 시간을 설정합니다 (기본값 DefaultNearCacheTTL)
+//
+// 추적을 사용할 수 없으면 이 시간이 다른 클라이언트의 변경이 반영되기까지의 최대 지연입니다
+func WithNearCacheTTL(ttl time.Duration) NearCacheOption {
+	return func(c *nearCacheConfig) {
+		c.ttl = ttl
+	}
+}
+
+// WithoutTracking은 CLIENT TRACKING을 사용하지 않고 TTL로만 로컬 항목을 만료시킵니다
+func WithoutTracking() NearCacheOption {
+	return func(c *nearCacheConfig) {
+		c.tracking = false
+	}
+}
+
+// WithNearCacheErrorHandler는 무효화 연결이 끊기는 등 호출자에게 반환할 수 없는 에러를 받을 함수를 설정합니다
+func WithNearCacheErrorHandler(fn func(error)) NearCacheOption {
+	return func(c *nearCacheConfig) {
+		c.onError = fn
+	}
+}
+
+// NearCache는 Get/HGetAll 결과를 프로세스 메모리에 보관하는 근접 캐시입니다
+//
+// Redis 6 이상의 CLIENT TRACKING을 사용해, 조회한 키가 다른 클라이언트에 의해 바뀌면
+// 서버가 보내는 무효화 메시지(RESP3 push)로 로컬 항목을 바로 지웁니다. 무효화 메시지는
+// 전용 연결로 받고 조회 연결은 CLIENT TRACKING REDIRECT로 그 연결에 알림을 넘깁니다.
+// 무효화 연결이 끊긴 동안에는 로컬 캐시를 비우고 사용하지 않으며, 다시 연결되면 재개합니다.
+// 서버나 권한이 추적을 지원하지 않거나 Cluster 연결이면 TTL로만 만료시킵니다
+type NearCache struct {
+	c   *Client
+	cfg nearCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// active는 로컬 캐시를 사용할 수 있는지 여부입니다 (무효화 연결이 끊긴 동안 false)
+	active bool
+	// seq는 무효화가 일어날 때마다 증가하며, 조회 중에 무효화된 결과를 저장하지 않는 데 사용합니다
+	seq      uint64
+	flushed  uint64
+	inflight map[string]int
+	dirty    map[string]uint64
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	evictions     atomic.Uint64
+	invalidations atomic.Uint64
+
+	// 추적을 사용할 때만 설정됨
+	listener   *redis.Client
+	sub        *Subscription
+	reader     *redis.Client
+	listenerID atomic.Int64
+	closeOnce  sync.Once
+}
+
+// nearEntry는 로컬에 저장된 Get 또는 HGetAll 결과입니다
+type nearEntry struct {
+	key     string
+	value   string
+	hash    map[string]string
+	isHash  bool
+	expires time.Time
+}
+
+// NearCache는 이 클라이언트 앞에 근접 캐시를 만듭니다
+//
+// 추적을 사용할 수 없으면 에러 대신 TTL만 사용하는 캐시를 반환합니다 (Tracking으로 확인).
+// 사용이 끝나면 Close로 무효화 연결을 닫아야 합니다
+func (c *Client) NearCache(ctx context.Context, opts ...NearCacheOption) (*NearCache, error) {
+	cfg := nearCacheConfig{
+		size:     DefaultNearCacheSize,
+		ttl:      DefaultNearCacheTTL,
+		tracking: true,
+		protocol: 3,
+		onError:  func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	n := &NearCache{
+		c:        c,
+		cfg:      cfg,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		active:   true,
+		inflight: make(map[string]int),
+		dirty:    make(map[string]uint64),
+	}
+	if !cfg.tracking {
+		return n, nil
+	}
+
+	err := n.startTracking(ctx)
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) || errors.Is(err, errTrackingUnsupported) {
+		// 서버가 CLIENT ID/TRACKING을 모르거나 권한이 없으면 TTL만 사용
+		n.stopTracking()
+		return n, nil
+	}
+	if err != nil {
+		n.stopTracking()
+		return nil, mapError(err)
+	}
+	return n, nil
+}
+
+// errTrackingUnsupported는 연결 방식이 추적을 지원하지 않을 때 사용됩니다
+var errTrackingUnsupported = errors.New("client tracking unsupported")
+
+// startTracking은 무효화 연결을 구독하고 그 연결로 알림을 넘기는 조회 클라이언트를 만듭니다
+func (n *NearCache) startTracking(ctx context.Context) error {
+	rc, ok := n.c.rdb.(*redis.Client)
+	if !ok {
+		return errTrackingUnsupported
+	}
+
+	opt := trackingOptions(rc)
+	opt.Protocol = n.cfg.protocol
+	opt.PoolSize = 1
+	opt.MinIdleConns = 0
+	opt.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+		// 재연결할 때마다 바뀌는 연결 ID를 조회 연결의 REDIRECT 대상으로 사용
+		id, err := cn.ClientID(ctx).Result()
+		if err != nil {
+			return err
+		}
+		n.listenerID.Store(id)
+		return nil
+	}
+	n.listener = redis.NewClient(&opt)
+	if opt.Protocol == 3 {
+		if err := n.listener.RegisterPushNotificationHandler("invalidate", invalidationHandler{n}, false); err != nil {
+			return err
+		}
+	}
+
+	listener := &Client{rdb: n.listener, codec: n.c.codec}
+	sub, err := listener.newSubscription(context.WithoutCancel(ctx), n.listener.Subscribe(ctx), func(ps *redis.PubSub) error {
+		return ps.Subscribe(ctx, invalidateChannel)
+	}, 1, []SubscribeOption{
+		WithResubscribeHandler(n.resubscribed),
+		WithSubscribeErrorHandler(n.lost),
+	})
+	if err != nil {
+		return err
+	}
+	n.sub = sub
+
+	// RESP2로 연결된 경우 무효화 알림은 Pub/Sub 메시지로 오며 payload가 무효화된 키 목록임
+	go func() {
+		for msg := range sub.Channel() {
+			n.invalidate(msg.PayloadSlice)
+		}
+	}()
+
+	reader, err := n.newReader(ctx)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.reader = reader
+	n.mu.Unlock()
+	return nil
+}
+
+// newReader는 모든 연결에서 무효화 알림을 현재 무효화 연결로 넘기는 조회 클라이언트를 만듭니다
+func (n *NearCache) newReader(ctx context.Context) (*redis.Client, error) {
+	opt := trackingOptions(n.c.rdb.(*redis.Client))
+	id := n.listenerID.Load()
+	opt.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+		return cn.Do(ctx, "CLIENT", "TRACKING", "ON", "REDIRECT", id).Err()
+	}
+
+	reader := redis.NewClient(&opt)
+	if err := reader.Ping(ctx).Err(); err != nil {
+		reader.Close()
+		return nil, err
+	}
+	return reader, nil
+}
+
+// trackingOptions는 원본 클라이언트의 설정을 복사합니다
+//
+// push 처리기는 원본과 공유하지 않도록 비워 두어 새 클라이언트가 자신의 처리기를 만들게 합니다
+func trackingOptions(rc *redis.Client) redis.Options {
+	opt := *rc.Options()
+	opt.PushNotificationProcessor = nil
+	return opt
+}
+
+// resubscribed는 무효화 연결이 다시 연결되면 새 연결 ID로 조회 클라이언트를 다시 만듭니다
+func (n *NearCache) resubscribed() {
+	reader, err := n.newReader(context.Background())
+
+	n.mu.Lock()
+	old := n.reader
+	n.reader = reader
+	n.flushLocked()
+	n.active = err == nil
+	n.mu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+	if err != nil {
+		n.cfg.onError(fmt.Errorf("near cache: tracking: %w", mapError(err)))
+	}
+}
+
+// lost는 무효화 연결이 끊기면 다시 연결될 때까지 로컬 캐시를 사용하지 않습니다
+//
+// RESP2의 FLUSHALL/FLUSHDB 무효화 메시지는 payload가 nil이라 메시지 대신 에러로 전달되므로
+// 이때는 연결을 유지한 채 로컬 캐시만 비웁니다
+func (n *NearCache) lost(err error) {
+	if isPayloadError(err) {
+		n.flush()
+		return
+	}
+
+	n.mu.Lock()
+	n.flushLocked()
+	n.active = false
+	n.mu.Unlock()
+	n.cfg.onError(fmt.Errorf("near cache: invalidation connection: %w", err))
+}
+
+// invalidationHandler는 RESP3 invalidate push 메시지를 처리합니다
+type invalidationHandler struct {
+	n *NearCache
+}
+
+func (h invalidationHandler) HandlePushNotification(_ context.Context, _ push.NotificationHandlerContext, notification []interface{}) error {
+	if len(notification) < 2 {
+		return nil
+	}
+	keys, ok := notification[1].([]interface{})
+	if !ok {
+		// FLUSHALL/FLUSHDB는 키 목록 없이 전달됨
+		h.n.flush()
+		return nil
+	}
+
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if s, ok := key.(string); ok {
+			names = append(names, s)
+		}
+	}
+	h.n.invalidate(names)
+	return nil
+}
+
+// Tracking은 CLIENT TRACKING으로 무효화 알림을 받고 있는지 반환합니다 (false이면 TTL만 사용)
+func (n *NearCache) Tracking() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.reader != nil
+}
+
+// Get은 로컬 캐시에서 키의 값을 찾고, 없으면 Redis에서 조회해 저장합니다
+//
+// 에러는 Client.Get과 같으며, 없는 키(ErrNotFound)는 저장하지 않습니다
+func (n *NearCache) Get(ctx context.Context, key string) (string, error) {
+	key = n.c.key(key)
+	if e, ok := n.lookup(key, false); ok {
+		return e.value, nil
+	}
+
+	e, err := n.fill(ctx, key, func(rdb redis.Cmdable) (*nearEntry, error) {
+		v, err := rdb.Get(ctx, key).Result()
+		return &nearEntry{value: v}, err
+	})
+	if err != nil {
+		return "", err
+	}
+	return e.value, nil
+}
+
+// HGetAll은 로컬 캐시에서 해시의 모든 필드를 찾고, 없으면 Redis에서 조회해 저장합니다
+//
+// 반환된 맵은 복사본이므로 변경해도 캐시에 영향이 없습니다
+func (n *NearCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	key = n.c.key(key)
+	if e, ok := n.lookup(key, true); ok {
+		return maps.Clone(e.hash), nil
+	}
+
+	e, err := n.fill(ctx, key, func(rdb redis.Cmdable) (*nearEntry, error) {
+		v, err := rdb.HGetAll(ctx, key).Result()
+		return &nearEntry{hash: v, isHash: true}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return maps.Clone(e.hash), nil
+}
+
+// Invalidate는 로컬 캐시에서 키를 지웁니다
+//
+// 추적을 사용하면 서버가 알려주므로 호출할 필요가 없지만, TTL만 사용할 때 이 프로세스에서
+// 변경한 값을 바로 반영하려면 쓰기 후에 호출합니다
+func (n *NearCache) Invalidate(keys ...string) {
+	n.invalidate(n.c.keys(keys))
+}
+
+// Stats는 적중, 미스, 제거 횟수와 현재 항목 수를 반환합니다
+func (n *NearCache) Stats() NearCacheStats {
+	n.mu.Lock()
+	size := n.lru.Len()
+	n.mu.Unlock()
+
+	return NearCacheStats{
+		Hits:          n.hits.Load(),
+		Misses:        n.misses.Load(),
+		Evictions:     n.evictions.Load(),
+		Invalidations: n.invalidations.Load(),
+		Size:          size,
+	}
+}
+
+// Close는 무효화 연결과 조회 연결을 닫고 로컬 캐시를 비웁니다 (원본 클라이언트는 닫지 않음)
+//
+// 닫은 뒤의 Get/HGetAll은 로컬 캐시 없이 Redis에서 조회합니다
+func (n *NearCache) Close() error {
+	n.closeOnce.Do(func() {
+		n.stopTracking()
+
+		n.mu.Lock()
+		n.flushLocked()
+		n.active = false
+		n.mu.Unlock()
+	})
+	return nil
+}
+
+// stopTracking은 무효화 연결과 조회 연결을 닫습니다
+func (n *NearCache) stopTracking() {
+	if n.sub != nil {
+		n.sub.Close()
+	}
+	if n.listener != nil {
+		n.listener.Close()
+	}
+
+	n.mu.Lock()
+	reader := n.reader
+	n.reader = nil
+	n.mu.Unlock()
+
+	if reader != nil {
+		reader.Close()
+	}
+}
+
+// lookup은 만료되지 않은 로컬 항목을 찾아 LRU의 맨 앞으로 옮깁니다
+func (n *NearCache) lookup(key string, isHash bool) (*nearEntry, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	el, ok := n.entries[key]
+	if !ok || !n.active {
+		return nil, false
+	}
+	e := el.Value.(*nearEntry)
+	if time.Now().After(e.expires) {
+		n.removeLocked(el)
+		return nil, false
+	}
+	if e.isHash != isHash {
+		return nil, false
+	}
+
+	n.lru.MoveToFront(el)
+	n.hits.Add(1)
+	return e, true
+}
+
+// fill은 Redis에서 값을 조회하고, 조회하는 동안 키가 무효화되지 않았으면 로컬에 저장합니다
+func (n *NearCache) fill(ctx context.Context, key string, fetch func(redis.Cmdable) (*nearEntry, error)) (*nearEntry, error) {
+	n.misses.Add(1)
+
+	n.mu.Lock()
+	start := n.seq
+	cacheable := n.active
+	var rdb redis.Cmdable = n.c.rdb
+	if n.reader != nil {
+		rdb = n.reader
+	}
+	n.inflight[key]++
+	n.mu.Unlock()
+
+	e, err := fetch(rdb)
+	if errors.Is(err, redis.ErrClosed) && rdb != n.c.rdb {
+		// 무효화 연결이 다시 연결되어 조회 클라이언트가 바뀐 경우 저장하지 않고 원래 연결로 조회
+		cacheable = false
+		e, err = fetch(n.c.rdb)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.inflight[key]--
+	fresh := n.dirty[key] <= start && n.flushed <= start
+	if n.inflight[key] == 0 {
+		delete(n.inflight, key)
+		delete(n.dirty, key)
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if cacheable && n.active && fresh {
+		n.putLocked(key, e)
+	}
+	return e, nil
+}
+
+// putLocked는 항목을 저장하고 용량을 넘으면 가장 오래 사용하지 않은 항목을 제거합니다
+func (n *NearCache) putLocked(key string, e *nearEntry) {
+	e.key = key
+	e.expires = time.Now().Add(n.cfg.ttl)
+
+	if el, ok := n.entries[key]; ok {
+		el.Value = e
+		n.lru.MoveToFront(el)
+		return
+	}
+	n.entries[key] = n.lru.PushFront(e)
+
+	for n.lru.Len() > max(n.cfg.size, 1) {
+		n.removeLocked(n.lru.Back())
+		n.evictions.Add(1)
+	}
+}
+
+func (n *NearCache) removeLocked(el *list.Element) {
+	n.lru.Remove(el)
+	delete(n.entries, el.Value.(*nearEntry).key)
+}
+
+// invalidate는 키를 로컬에서 지우고, 조회 중인 키는 결과를 저장하지 않도록 표시합니다
+func (n *NearCache) invalidate(keys []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.seq++
+	for _, key := range keys {
+		if el, ok := n.entries[key]; ok {
+			n.removeLocked(el)
+			n.invalidations.Add(1)
+		}
+		if n.inflight[key] > 0 {
+			n.dirty[key] = n.seq
+		}
+	}
+}
+
+// flush는 로컬 캐시를 모두 비우고 조회 중인 결과도 저장하지 않도록 합니다
+func (n *NearCache) flush() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.flushLocked()
+}
+
+func (n *NearCache) flushLocked() {
+	n.seq++
+	n.flushed = n.seq
+	n.invalidations.Add(uint64(n.lru.Len()))
+	clear(n.entries)
+	n.lru.Init()
+}
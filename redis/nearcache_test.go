@@ -0,0 +1,278 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisNearCacheTTL(t *testing.T) {
+	ctx := context.Background()
+
+	near, err := testClient.NearCache(ctx, WithoutTracking(), WithNearCacheTTL(300*time.Millisecond), WithNearCacheSize(2))
+	require.NoError(t, err)
+	defer near.Close()
+	assert.False(t, near.Tracking())
+
+	// 테스트 전 키 정리
+	_ = testClient.Delete(ctx, "near:a", "near:b", "near:c", "near:hash", "near:missing")
+	require.NoError(t, testClient.Set(ctx, "near:a", "v1", time.Minute))
+
+	// 처음은 미스, 다음은 적중
+	for range 2 {
+		value, err := near.Get(ctx, "near:a")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", value)
+	}
+	stats := near.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+
+	// 추적 없이는 TTL이 지날 때까지 이전 값을 반환
+	require.NoError(t, testClient.Set(ctx, "near:a", "v2", time.Minute))
+	value, err := near.Get(ctx, "near:a")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", value)
+	require.Eventually(t, func() bool {
+		value, err := near.Get(ctx, "near:a")
+		return err == nil && value == "v2"
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// Invalidate 후에는 바로 새 값
+	require.NoError(t, testClient.Set(ctx, "near:a", "v3", time.Minute))
+	near.Invalidate("near:a")
+	value, err = near.Get(ctx, "near:a")
+	require.NoError(t, err)
+	assert.Equal(t, "v3", value)
+
+	// 없는 키는 저장하지 않음
+	_, err = near.Get(ctx, "near:missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, near.Stats().Size)
+
+	// 용량을 넘으면 가장 오래 사용하지 않은 항목을 제거
+	require.NoError(t, testClient.Set(ctx, "near:b", "b", time.Minute))
+	require.NoError(t, testClient.Set(ctx, "near:c", "c", time.Minute))
+	_, err = near.Get(ctx, "near:b")
+	require.NoError(t, err)
+	_, err = near.Get(ctx, "near:a")
+	require.NoError(t, err)
+	_, err = near.Get(ctx, "near:c")
+	require.NoError(t, err)
+	stats = near.Stats()
+	assert.Equal(t, 2, stats.Size)
+	assert.Equal(t, uint64(1), stats.Evictions)
+
+	hits := stats.Hits
+	_, err = near.Get(ctx, "near:a")
+	require.NoError(t, err)
+	_, err = near.Get(ctx, "near:b")
+	require.NoError(t, err)
+	assert.Equal(t, hits+1, near.Stats().Hits)
+
+	// HGetAll은 복사본을 반환
+	require.NoError(t, testClient.HSet(ctx, "near:hash", "name", "alice"))
+	fields, err := near.HGetAll(ctx, "near:hash")
+	require.NoError(t, err)
+	fields["name"] = "changed"
+	fields, err = near.HGetAll(ctx, "near:hash")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "alice"}, fields)
+}
+
+func TestRedisNearCacheNamespace(t *testing.T) {
+	ctx := context.Background()
+	ns := testClient.Namespace("nearns")
+
+	near, err := ns.NearCache(ctx, WithoutTracking())
+	require.NoError(t, err)
+	defer near.Close()
+
+	require.NoError(t, ns.Set(ctx, "key", "scoped", time.Minute))
+	value, err := near.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "scoped", value)
+
+	// 접두사가 붙은 키로 저장되므로 같은 이름의 루트 키와 섞이지 않음
+	require.NoError(t, testClient.Set(ctx, "key", "root", time.Minute))
+	defer testClient.Delete(ctx, "key")
+	rootNear, err := testClient.NearCache(ctx, WithoutTracking())
+	require.NoError(t, err)
+	defer rootNear.Close()
+	value, err = rootNear.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "root", value)
+
+	require.NoError(t, ns.Set(ctx, "key", "updated", time.Minute))
+	near.Invalidate("key")
+	value, err = near.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", value)
+
+	// 닫은 뒤에는 캐시 없이 조회
+	require.NoError(t, near.Close())
+	require.NoError(t, ns.Set(ctx, "key", "closed", time.Minute))
+	value, err = near.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "closed", value)
+	assert.Zero(t, near.Stats().Size)
+}
+
+func TestRedisNearCacheTracking(t *testing.T) {
+	ctx := context.Background()
+
+	near, err := testClient.NearCache(ctx)
+	require.NoError(t, err)
+	defer near.Close()
+	require.True(t, near.Tracking())
+
+	// 테스트 전 키 정리
+	_ = testClient.Delete(ctx, "tracking:key", "tracking:hash")
+	require.NoError(t, testClient.Set(ctx, "tracking:key", "v1", time.Minute))
+	require.NoError(t, testClient.HSet(ctx, "tracking:hash", "name", "alice"))
+
+	value, err := near.Get(ctx, "tracking:key")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", value)
+	_, err = near.HGetAll(ctx, "tracking:hash")
+	require.NoError(t, err)
+	assert.Equal(t, 2, near.Stats().Size)
+
+	// 다른 클라이언트가 바꾸면 TTL 전이라도 무효화 알림으로 지워짐
+	require.NoError(t, testClient.Set(ctx, "tracking:key", "v2", time.Minute))
+	require.NoError(t, testClient.HSet(ctx, "tracking:hash", "name", "bob"))
+	require.Eventually(t, func() bool {
+		return near.Stats().Invalidations == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	value, err = near.Get(ctx, "tracking:key")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", value)
+	fields, err := near.HGetAll(ctx, "tracking:hash")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "bob"}, fields)
+
+	// 다시 조회한 값도 계속 추적
+	require.NoError(t, testClient.Delete(ctx, "tracking:key"))
+	require.Eventually(t, func() bool {
+		_, err := near.Get(ctx, "tracking:key")
+		return errors.Is(err, ErrNotFound)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestRedisNearCacheTrackingRESP2(t *testing.T) {
+	ctx := context.Background()
+
+	// 무효화 연결이 RESP2이면 알림이 __redis__:invalidate 채널의 메시지로 옴
+	var errs atomic.Int32
+	near, err := testClient.NearCache(ctx, func(c *nearCacheConfig) { c.protocol = 2 },
+		WithNearCacheErrorHandler(func(error) { errs.Add(1) }))
+	require.NoError(t, err)
+	defer near.Close()
+	require.True(t, near.Tracking())
+
+	_ = testClient.Delete(ctx, "resp2:a", "resp2:b")
+	require.NoError(t, testClient.Set(ctx, "resp2:a", "a1", time.Minute))
+	require.NoError(t, testClient.Set(ctx, "resp2:b", "b1", time.Minute))
+	for _, key := range []string{"resp2:a", "resp2:b"} {
+		_, err := near.Get(ctx, key)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, near.Stats().Size)
+
+	// 메시지에 담긴 키만 지움
+	require.NoError(t, testClient.Set(ctx, "resp2:a", "a2", time.Minute))
+	require.Eventually(t, func() bool {
+		return near.Stats().Invalidations == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, near.Stats().Size)
+	value, err := near.Get(ctx, "resp2:a")
+	require.NoError(t, err)
+	assert.Equal(t, "a2", value)
+
+	// FLUSHDB의 nil payload는 전체를 비우지만 연결은 유지되어 계속 캐시함
+	// (flush 알림은 DB와 관계없이 전달되므로 다른 테스트의 키가 없는 DB를 비움)
+	other := goredis.NewClient(&goredis.Options{Addr: testEndpoint, DB: 9})
+	defer other.Close()
+	require.NoError(t, other.FlushDB(ctx).Err())
+	require.Eventually(t, func() bool {
+		return near.Stats().Size == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Zero(t, errs.Load())
+
+	require.NoError(t, testClient.Set(ctx, "resp2:a", "a3", time.Minute))
+	value, err = near.Get(ctx, "resp2:a")
+	require.NoError(t, err)
+	assert.Equal(t, "a3", value)
+	assert.Equal(t, 1, near.Stats().Size)
+}
+
+func TestRedisNearCacheReconnect(t *testing.T) {
+	ctx := context.Background()
+
+	// 프록시를 거쳐 연결한 클라이언트
+	proxy := newDropProxy(t, testEndpoint)
+	client := NewClient(proxy.Addr())
+	defer client.Close()
+
+	var connErrors atomic.Int32
+	near, err := client.NearCache(ctx, WithNearCacheErrorHandler(func(error) { connErrors.Add(1) }))
+	require.NoError(t, err)
+	defer near.Close()
+	require.True(t, near.Tracking())
+
+	_ = testClient.Delete(ctx, "reconnect:near")
+	require.NoError(t, testClient.Set(ctx, "reconnect:near", "before", time.Minute))
+	value, err := near.Get(ctx, "reconnect:near")
+	require.NoError(t, err)
+	assert.Equal(t, "before", value)
+
+	// 연결이 끊기면 로컬 캐시를 비우고, 다시 연결되면 새 연결로 추적을 재개
+	proxy.drop()
+	require.Eventually(t, func() bool { return connErrors.Load() > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		value, err := near.Get(ctx, "reconnect:near")
+		return err == nil && value == "before" && near.Stats().Size == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, testClient.Set(ctx, "reconnect:near", "after", time.Minute))
+	require.Eventually(t, func() bool {
+		value, err := near.Get(ctx, "reconnect:near")
+		return err == nil && value == "after"
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestRedisNearCacheFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// CLIENT 명령을 사용할 수 없는 ACL 사용자
+	admin := goredis.NewClient(&goredis.Options{Addr: testEndpoint})
+	defer admin.Close()
+	require.NoError(t, admin.Do(ctx, "ACL", "SETUSER", "near", "reset", "on", ">secret", "~*", "+@all", "-client").Err())
+	defer admin.Do(ctx, "ACL", "DELUSER", "near")
+
+	client, err := New(WithAddr(testEndpoint), WithCredentials("near", "secret"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	// 추적 대신 TTL만 사용
+	near, err := client.NearCache(ctx, WithNearCacheTTL(time.Minute))
+	require.NoError(t, err)
+	defer near.Close()
+	assert.False(t, near.Tracking())
+
+	require.NoError(t, client.Set(ctx, "fallback:near", "cached", time.Minute))
+	for range 2 {
+		value, err := near.Get(ctx, "fallback:near")
+		require.NoError(t, err)
+		assert.Equal(t, "cached", value)
+	}
+	assert.Equal(t, uint64(1), near.Stats().Hits)
+}
@@ -0,0 +1,256 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrInvalidOptions는 New에 전달한 연결 설정이 올바르지 않을 때 반환됩니다
+var ErrInvalidOptions = errors.New("invalid redis options")
+
+// options는 New에 적용되는 설정입니다
+type options struct {
+	addr string
+
+	masterName       string
+	sentinelAddrs    []string
+	sentinelUsername string
+	sentinelPassword string
+
+	clusterAddrs []string
+
+	username string
+	password string
+	db       int
+
+	tlsConfig *tls.Config
+	caCerts   [][]byte
+	codec     Codec
+	scripts   *ScriptRegistry
+}
+
+// Option은 New의 연결 방식과 인증, TLS 등을 설정합니다
+type Option func(*options)
+
+// WithAddr는 단일 노드의 주소("host:port")를 설정합니다
+func WithAddr(addr string) Option {
+	return func(o *options) {
+		o.addr = addr
+	}
+}
+
+// WithSentinel은 Sentinel로 masterName의 현재 마스터를 찾아 연결하도록 설정합니다
+//
+// 장애 조치로 마스터가 바뀌면 Sentinel에 다시 물어 새 마스터로 연결합니다
+func WithSentinel(masterName string, sentinelAddrs ...string) Option {
+	return func(o *options) {
+		o.masterName = masterName
+		o.sentinelAddrs = sentinelAddrs
+	}
+}
+
+// WithSentinelCredentials는 Sentinel 노드 자체의 ACL 사용자와 비밀번호를 설정합니다
+//
+// 마스터와 레플리카의 인증은 WithCredentials로 설정합니다
+func WithSentinelCredentials(username, password string) Option {
+	return func(o *options) {
+		o.sentinelUsername = username
+		o.sentinelPassword = password
+	}
+}
+
+// WithCluster는 Redis Cluster에 연결하도록 설정합니다 (addrs는 시드 노드 주소)
+//
+// Delete와 Exists는 키를 하나씩 나누어 실행하므로 서로 다른 슬롯의 키도 받지만, 여러 키를
+// 한 번에 다루는 나머지 명령(Batch의 Delete/Exists, SInter, Watch, 스크립트)은 키가 같은 슬롯에
+// 있어야 하므로 "{user:1}:profile"처럼 해시 태그를 사용해야 합니다 (CROSSSLOT 에러).
+// 락, 큐, 순위표는 자체적으로 해시 태그를 붙입니다
+func WithCluster(addrs ...string) Option {
+	return func(o *options) {
+		o.clusterAddrs = addrs
+	}
+}
+
+// WithCredentials는 ACL 사용자와 비밀번호를 설정합니다 (username이 비어 있으면 default 사용자)
+func WithCredentials(username, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithDB는 사용할 DB 번호를 설정합니다 (Cluster에서는 0만 사용 가능)
+func WithDB(db int) Option {
+	return func(o *options) {
+		o.db = db
+	}
+}
+
+// WithTLS는 TLS로 연결하도록 설정합니다
+//
+// cfg는 복사해서 사용하므로 이후에 변경해도 영향이 없습니다
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg.Clone()
+	}
+}
+
+// WithCACert는 서버 인증서를 검증할 CA 인증서(PEM)를 추가하고 TLS를 사용하도록 설정합니다
+//
+// WithTLS와 함께 사용하면 그 설정의 RootCAs에 추가합니다
+func WithCACert(pem []byte) Option {
+	return func(o *options) {
+		o.caCerts = append(o.caCerts, pem)
+	}
+}
+
+// WithCodec은 SetValue/GetValue 등에서 사용할 Codec을 설정합니다 (기본값은 JSONCodec)
+func WithCodec(codec Codec) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// WithScripts는 새 연결을 맺을 때마다 registry의 스크립트를 미리 올리도록 설정합니다
+//
+// 시작 시 첫 연결과 서버 재시작, 장애 조치 뒤의 재연결에서 모두 SCRIPT LOAD를 실행합니다
+func WithScripts(registry *ScriptRegistry) Option {
+	return func(o *options) {
+		o.scripts = registry
+	}
+}
+
+// New는 옵션으로 단일 노드, Sentinel 또는 Cluster 클라이언트를 생성합니다
+//
+// WithAddr, WithSentinel, WithCluster 중 하나를 지정해야 하며, 모든 래퍼 메서드는
+// 연결 방식과 관계없이 같은 방식으로 동작합니다 (Cluster에서 여러 키를 다루는 명령은 WithCluster 참고)
+func New(opts ...Option) (*Client, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var rdb redis.UniversalClient
+	switch {
+	case o.masterName != "":
+		rdb = redis.NewFailoverClient(o.failoverOptions())
+	case len(o.clusterAddrs) > 0:
+		rdb = redis.NewClusterClient(o.clusterOptions())
+	default:
+		rdb = redis.NewClient(o.clientOptions())
+	}
+	return &Client{rdb: rdb, codec: o.codec}, nil
+}
+
+// newOptions는 옵션을 적용하고 검증합니다
+func newOptions(opts []Option) (*options, error) {
+	o := &options{codec: JSONCodec{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if err := o.buildTLS(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// validate는 연결 방식이 하나만 지정되었는지 확인합니다
+func (o *options) validate() error {
+	modes := 0
+	if o.addr != "" {
+		modes++
+	}
+	if o.masterName != "" {
+		modes++
+		if len(o.sentinelAddrs) == 0 {
+			return fmt.Errorf("%w: sentinel addresses required", ErrInvalidOptions)
+		}
+	}
+	if len(o.clusterAddrs) > 0 {
+		modes++
+		if o.db != 0 {
+			return fmt.Errorf("%w: cluster supports only db 0", ErrInvalidOptions)
+		}
+	}
+
+	switch {
+	case modes == 0:
+		return fmt.Errorf("%w: one of address, sentinel or cluster required", ErrInvalidOptions)
+	case modes > 1:
+		return fmt.Errorf("%w: address, sentinel and cluster are mutually exclusive", ErrInvalidOptions)
+	case o.db < 0:
+		return fmt.Errorf("%w: negative db %d", ErrInvalidOptions, o.db)
+	}
+	return nil
+}
+
+// buildTLS는 CA 인증서를 TLS 설정의 RootCAs에 추가합니다
+func (o *options) buildTLS() error {
+	if len(o.caCerts) == 0 {
+		return nil
+	}
+	if o.tlsConfig == nil {
+		o.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if o.tlsConfig.RootCAs == nil {
+		o.tlsConfig.RootCAs = x509.NewCertPool()
+	}
+	for _, pem := range o.caCerts {
+		if !o.tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: no certificates found in CA PEM", ErrInvalidOptions)
+		}
+	}
+	return nil
+}
+
+// onConnect는 새 연결마다 실행할 함수를 반환합니다 (없으면 nil)
+func (o *options) onConnect() func(context.Context, *redis.Conn) error {
+	if o.scripts == nil {
+		return nil
+	}
+	return o.scripts.onConnect
+}
+
+func (o *options) clientOptions() *redis.Options {
+	return &redis.Options{
+		Addr:      o.addr,
+		Username:  o.username,
+		Password:  o.password,
+		DB:        o.db,
+		TLSConfig: o.tlsConfig,
+		OnConnect: o.onConnect(),
+	}
+}
+
+func (o *options) failoverOptions() *redis.FailoverOptions {
+	return &redis.FailoverOptions{
+		MasterName:       o.masterName,
+		SentinelAddrs:    o.sentinelAddrs,
+		SentinelUsername: o.sentinelUsername,
+		SentinelPassword: o.sentinelPassword,
+		Username:         o.username,
+		Password:         o.password,
+		DB:               o.db,
+		TLSConfig:        o.tlsConfig,
+		OnConnect:        o.onConnect(),
+	}
+}
+
+func (o *options) clusterOptions() *redis.ClusterOptions {
+	return &redis.ClusterOptions{
+		Addrs:     o.clusterAddrs,
+		Username:  o.username,
+		Password:  o.password,
+		TLSConfig: o.tlsConfig,
+		OnConnect: o.onConnect(),
+	}
+}
@@ -0,0 +1,266 @@
+package redis
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func TestNewOptions(t *testing.T) {
+	// 연결 방식은 정확히 하나
+	invalid := [][]Option{
+		nil,
+		{WithAddr("localhost:6379"), WithCluster("localhost:7000")},
+		{WithAddr("localhost:6379"), WithSentinel("mymaster", "localhost:26379")},
+		{WithSentinel("mymaster")},
+		{WithCluster("localhost:7000"), WithDB(1)},
+		{WithAddr("localhost:6379"), WithDB(-1)},
+		{WithAddr("localhost:6379"), WithCACert([]byte("not a certificate"))},
+	}
+	for i, opts := range invalid {
+		_, err := New(opts...)
+		assert.ErrorIs(t, err, ErrInvalidOptions, i)
+	}
+
+	// Sentinel 설정
+	caPEM, _ := newTestCertificates(t)
+	o, err := newOptions([]Option{
+		WithSentinel("mymaster", "sentinel-1:26379", "sentinel-2:26379"),
+		WithSentinelCredentials("sentinel-user", "sentinel-pass"),
+		WithCredentials("app", "secret"),
+		WithDB(2),
+		WithCACert(caPEM),
+	})
+	require.NoError(t, err)
+
+	failover := o.failoverOptions()
+	assert.Equal(t, "mymaster", failover.MasterName)
+	assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379"}, failover.SentinelAddrs)
+	assert.Equal(t, "sentinel-user", failover.SentinelUsername)
+	assert.Equal(t, "sentinel-pass", failover.SentinelPassword)
+	assert.Equal(t, "app", failover.Username)
+	assert.Equal(t, "secret", failover.Password)
+	assert.Equal(t, 2, failover.DB)
+	require.NotNil(t, failover.TLSConfig)
+	assert.NotNil(t, failover.TLSConfig.RootCAs)
+
+	// 전달한 TLS 설정은 변경하지 않음
+	base := &tls.Config{ServerName: "redis.internal"}
+	o, err = newOptions([]Option{WithCluster("node-1:7000", "node-2:7000"), WithTLS(base), WithCACert(caPEM)})
+	require.NoError(t, err)
+
+	cluster := o.clusterOptions()
+	assert.Equal(t, []string{"node-1:7000", "node-2:7000"}, cluster.Addrs)
+	assert.Equal(t, "redis.internal", cluster.TLSConfig.ServerName)
+	assert.NotNil(t, cluster.TLSConfig.RootCAs)
+	assert.Nil(t, base.RootCAs)
+
+	client, err := New(WithSentinel("mymaster", "sentinel-1:26379"))
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+}
+
+func TestRedisCredentialsAndDB(t *testing.T) {
+	ctx := context.Background()
+
+	// app:* 키만 사용할 수 있는 ACL 사용자
+	admin := goredis.NewClient(&goredis.Options{Addr: testEndpoint})
+	defer admin.Close()
+	require.NoError(t, admin.Do(ctx, "ACL", "SETUSER", "app", "reset", "on", ">secret", "~app:*", "+@all").Err())
+	defer admin.Do(ctx, "ACL", "DELUSER", "app")
+
+	client, err := New(WithAddr(testEndpoint), WithCredentials("app", "secret"), WithDB(3))
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Set(ctx, "app:greeting", "hello", time.Minute))
+	value, err := client.Get(ctx, "app:greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", value)
+
+	// 다른 DB에 저장됨
+	n, err := testClient.Exists(ctx, "app:greeting")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// 허용되지 않은 키
+	err = client.Set(ctx, "other:greeting", "hello", time.Minute)
+	assert.ErrorContains(t, err, "NOPERM")
+
+	// 잘못된 비밀번호
+	wrong, err := New(WithAddr(testEndpoint), WithCredentials("app", "wrong"))
+	require.NoError(t, err)
+	defer wrong.Close()
+	assert.ErrorContains(t, wrong.Ping(ctx), "WRONGPASS")
+}
+
+func TestRedisTLS(t *testing.T) {
+	ctx := context.Background()
+	caPEM, serverCert := newTestCertificates(t)
+
+	// TLS를 종료하고 테스트 Redis로 중계하는 프록시
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	require.NoError(t, err)
+	proxy := newProxy(t, listener, testEndpoint)
+
+	client, err := New(WithAddr(proxy.Addr()), WithCACert(caPEM))
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Set(ctx, "tls:key", "secure", time.Minute))
+	value, err := client.Get(ctx, "tls:key")
+	require.NoError(t, err)
+	assert.Equal(t, "secure", value)
+
+	// CA를 모르면 서버 인증서 검증 실패
+	untrusted, err := New(WithAddr(proxy.Addr()), WithTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	require.NoError(t, err)
+	defer untrusted.Close()
+
+	var unknownAuthority x509.UnknownAuthorityError
+	assert.ErrorAs(t, untrusted.Ping(ctx), &unknownAuthority)
+}
+
+func TestRedisCluster(t *testing.T) {
+	ctx := context.Background()
+	endpoint := setupCluster(t)
+
+	client, err := New(WithCluster(endpoint))
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Ping(ctx))
+
+	// 래퍼 메서드는 Cluster에서도 그대로 동작
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("cluster:key:%d", i), i, time.Minute))
+	}
+	value, err := client.Get(ctx, "cluster:key:7")
+	require.NoError(t, err)
+	assert.Equal(t, "7", value)
+
+	_, err = client.Get(ctx, "cluster:missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Scan은 모든 마스터 노드를 순회
+	var keys []string
+	for key, err := range client.Scan(ctx, "cluster:key:*", 100) {
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+	assert.Len(t, keys, 10)
+
+	// 여러 슬롯에 걸친 키도 Delete/Exists로 한 번에 처리
+	n, err := client.Exists(ctx, "cluster:key:1", "cluster:key:2", "cluster:missing")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	require.NoError(t, client.Delete(ctx, "cluster:key:1", "cluster:key:2"))
+	n, err = client.Exists(ctx, "cluster:key:1", "cluster:key:2")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// 파이프라인은 노드별로 나누어 실행
+	b := client.Pipeline()
+	first := b.Increment("cluster:counter:a")
+	second := b.Increment("cluster:counter:b")
+	require.NoError(t, b.Exec(ctx))
+	assert.Equal(t, int64(1), first.Val())
+	assert.Equal(t, int64(1), second.Val())
+
+	// 락과 펜싱 토큰 키는 해시 태그로 같은 슬롯에 놓임
+	lock, err := client.TryLock(ctx, "cluster:order:1", WithLockTTL(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), lock.Fence())
+	require.NoError(t, lock.Unlock(ctx))
+
+	sub, err := client.Subscribe(ctx, []string{"cluster:events"})
+	require.NoError(t, err)
+	defer sub.Close()
+	_, err = client.Publish(ctx, "cluster:events", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", receive(t, sub).Payload)
+}
+
+// setupCluster는 모든 슬롯을 가진 단일 노드 Redis Cluster 컨테이너를 시작합니다
+func setupCluster(t *testing.T) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	container, err := redisModule.Run(ctx, "redis:7.2", testcontainers.WithCmdArgs("--cluster-enabled", "yes"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = testcontainers.TerminateContainer(container)
+	})
+
+	endpoint, err := container.Endpoint(ctx, "")
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+
+	// 컨테이너 밖의 클라이언트가 접속할 수 있도록 호스트와 매핑된 포트를 노드 주소로 알림
+	admin := goredis.NewClient(&goredis.Options{Addr: endpoint})
+	defer admin.Close()
+	require.NoError(t, admin.ConfigSet(ctx, "cluster-announce-hostname", host).Err())
+	require.NoError(t, admin.ConfigSet(ctx, "cluster-preferred-endpoint-type", "hostname").Err())
+	require.NoError(t, admin.ConfigSet(ctx, "cluster-announce-port", port).Err())
+	require.NoError(t, admin.ClusterAddSlotsRange(ctx, 0, 16383).Err())
+
+	require.Eventually(t, func() bool {
+		info, err := admin.ClusterInfo(ctx).Result()
+		return err == nil && strings.Contains(info, "cluster_state:ok")
+	}, 30*time.Second, 100*time.Millisecond)
+	return endpoint
+}
+
+// newTestCertificates는 테스트용 CA(PEM)와 127.0.0.1용 서버 인증서를 만듭니다
+func newTestCertificates(t *testing.T) ([]byte, tls.Certificate) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "redis"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, ca, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	return caPEM, tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey}
+}
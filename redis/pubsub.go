@@ -0,0 +1,293 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 구독 기본 설정값
+const (
+	DefaultSubscriptionBuffer = 100
+	DefaultHealthCheck        = 30 * time.Second
+)
+
+// resubscribeBackoff는 구독 연결이 끊긴 뒤 다시 연결하기 전 대기 시간입니다
+const resubscribeBackoff = 100 * time.Millisecond
+
+// Message는 Pub/Sub으로 받은 메시지입니다
+type Message struct {
+	Channel string
+	// Pattern은 패턴 구독으로 받은 경우 일치한 패턴입니다
+	Pattern string
+	Payload string
+	// PayloadSlice는 payload가 배열인 경우의 값입니다 (RESP2 무효화 메시지의 키 목록 등)
+	PayloadSlice []string
+}
+
+// subscribeConfig는 Subscription 설정입니다
+type subscribeConfig struct {
+	buffer      int
+	healthCheck time.Duration
+	onResub     func()
+	onError     func(error)
+}
+
+// SubscribeOption은 구독 동작을 설정합니다
+type SubscribeOption func(*subscribeConfig)
+
+// WithSubscriptionBuffer는 메시지 채널의 버퍼 크기를 설정합니다 (기본값 DefaultSubscriptionBuffer)
+//
+// 버퍼가 가득 차면 소비할 때까지 다음 메시지를 읽지 않습니다
+func WithSubscriptionBuffer(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.buffer = n
+	}
+}
+
+// WithHealthCheck는 메시지가 없을 때 연결을 확인하는 주기를 설정합니다 (기본값 DefaultHealthCheck)
+func WithHealthCheck(d time.Duration) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.healthCheck = d
+	}
+}
+
+// WithResubscribeHandler는 연결이 끊겼다가 다시 구독되었을 때 호출할 함수를 설정합니다
+//
+// 연결이 끊긴 동안 발행된 메시지는 받지 못하므로, 캐시 무효화처럼 유실되면 안 되는 경우
+// 이 함수에서 로컬 상태를 초기화해야 합니다
+func WithResubscribeHandler(fn func()) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.onResub = fn
+	}
+}
+
+// WithSubscribeErrorHandler는 연결 에러와 메시지 디코딩 에러를 받을 함수를 설정합니다
+func WithSubscribeErrorHandler(fn func(error)) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.onError = fn
+	}
+}
+
+// Subscription은 채널 또는 패턴 구독입니다
+//
+// 연결이 끊기면 자동으로 다시 연결해 같은 채널과 패턴을 구독합니다
+type Subscription struct {
+	c   *Client
+	ps  *redis.PubSub
+	cfg subscribeConfig
+	ch  chan Message
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// Publish는 channel에 message를 발행하고 메시지를 받은 구독자 수를 반환합니다
+func (c *Client) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	v, err := c.rdb.Publish(ctx, c.key(channel), message).Result()
+	return v, mapError(err)
+}
+
+// PublishValue는 v를 클라이언트의 Codec으로 인코딩해 channel에 발행합니다
+func PublishValue[T any](ctx context.Context, c *Client, channel string, v T) (int64, error) {
+	data, err := c.codec.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	return c.Publish(ctx, channel, data)
+}
+
+// DecodeMessage는 메시지 페이로드를 클라이언트의 Codec으로 디코딩합니다
+func DecodeMessage[T any](c *Client, msg Message) (T, error) {
+	var v T
+	err := c.codec.Unmarshal([]byte(msg.Payload), &v)
+	return v, err
+}
+
+// Subscribe는 channels를 구독합니다
+//
+// 서버가 구독을 확인한 뒤 반환하므로 이후에 발행된 메시지는 모두 받습니다.
+// ctx가 취소되거나 Close를 호출하면 구독이 끝나고 Channel이 닫힙니다.
+// Namespace 뷰에서는 채널에도 접두사가 붙고, 받은 Message의 채널은 접두사를 뗀 이름입니다
+func (c *Client) Subscribe(ctx context.Context, channels []string, opts ...SubscribeOption) (*Subscription, error) {
+	return c.newSubscription(ctx, c.rdb.Subscribe(ctx), func(ps *redis.PubSub) error {
+		return ps.Subscribe(ctx, c.keys(channels)...)
+	}, len(channels), opts)
+}
+
+// PSubscribe는 patterns와 일치하는 채널을 구독합니다 (예: "orders:*")
+func (c *Client) PSubscribe(ctx context.Context, patterns []string, opts ...SubscribeOption) (*Subscription, error) {
+	prefixed := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		prefixed[i] = escapeGlob(c.prefix) + pattern
+	}
+	return c.newSubscription(ctx, c.rdb.PSubscribe(ctx), func(ps *redis.PubSub) error {
+		return ps.PSubscribe(ctx, prefixed...)
+	}, len(patterns), opts)
+}
+
+// SubscribeFunc는 channels를 구독하고 메시지를 T로 디코딩해 fn을 순서대로 호출합니다
+//
+// 디코딩에 실패한 메시지는 건너뛰고 WithSubscribeErrorHandler로 전달합니다
+func SubscribeFunc[T any](ctx context.Context, c *Client, channels []string, fn func(ctx context.Context, channel string, v T), opts ...SubscribeOption) (*Subscription, error) {
+	sub, err := c.Subscribe(ctx, channels, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for msg := range sub.Channel() {
+			v, err := DecodeMessage[T](c, msg)
+			if err != nil {
+				sub.cfg.onError(err)
+				continue
+			}
+			fn(ctx, msg.Channel, v)
+		}
+	}()
+	return sub, nil
+}
+
+// newSubscription은 구독을 확인한 뒤 메시지 수신을 시작합니다
+func (c *Client) newSubscription(ctx context.Context, ps *redis.PubSub, subscribe func(*redis.PubSub) error, n int, opts []SubscribeOption) (*Subscription, error) {
+	cfg := subscribeConfig{
+		buffer:      DefaultSubscriptionBuffer,
+		healthCheck: DefaultHealthCheck,
+		onResub:     func() {},
+		onError:     func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if err := subscribe(ps); err != nil {
+		ps.Close()
+		return nil, mapError(err)
+	}
+	for i := 0; i < n; i++ {
+		msg, err := ps.Receive(ctx)
+		if err != nil {
+			ps.Close()
+			return nil, mapError(err)
+		}
+		if _, ok := msg.(*redis.Subscription); !ok {
+			i--
+		}
+	}
+
+	s := &Subscription{
+		c:       c,
+		ps:      ps,
+		cfg:     cfg,
+		ch:      make(chan Message, max(cfg.buffer, 0)),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go s.run(ctx)
+	return s, nil
+}
+
+// Channel은 받은 메시지를 전달하는 채널을 반환합니다
+func (s *Subscription) Channel() <-chan Message {
+	return s.ch
+}
+
+// Close는 구독을 끝내고 Channel을 닫습니다
+func (s *Subscription) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.ps.Close()
+	})
+	<-s.stopped
+	return err
+}
+
+// run은 메시지를 받아 채널로 전달하고, 연결이 끊기면 다시 구독합니다
+func (s *Subscription) run(ctx context.Context) {
+	defer close(s.stopped)
+	defer close(s.ch)
+
+	// 블록된 수신을 깨우기 위해 ctx가 취소되면 연결을 닫음
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.closeOnce.Do(func() {
+				close(s.done)
+				s.ps.Close()
+			})
+		case <-s.done:
+		}
+	}()
+
+	lost := false
+	for {
+		msg, err := s.ps.ReceiveTimeout(ctx, s.cfg.healthCheck)
+		if s.closed() {
+			return
+		}
+		if err != nil && isPayloadError(err) {
+			// 메시지를 해석하지 못했을 뿐 연결은 그대로이므로 알리고 계속 수신
+			s.cfg.onError(err)
+			continue
+		}
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() && !lost {
+				// 메시지가 없는 동안 연결 확인 (실패하면 다시 연결됨)
+				if err := s.ps.Ping(ctx); err == nil {
+					continue
+				}
+			}
+			if !lost {
+				lost = true
+				s.cfg.onError(mapError(err))
+			}
+			select {
+			case <-s.done:
+				return
+			case <-time.After(resubscribeBackoff):
+			}
+			continue
+		}
+
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			if lost {
+				lost = false
+				s.cfg.onResub()
+			}
+		case *redis.Message:
+			select {
+			case s.ch <- s.message(m):
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// message는 받은 메시지에서 네임스페이스 접두사를 떼어 Message로 변환합니다
+func (s *Subscription) message(m *redis.Message) Message {
+	msg := Message{Channel: s.c.trimKey(m.Channel), Payload: m.Payload, PayloadSlice: m.PayloadSlice}
+	if m.Pattern != "" {
+		msg.Pattern = strings.TrimPrefix(m.Pattern, escapeGlob(s.c.prefix))
+	}
+	return msg
+}
+
+// closed는 구독이 끝났는지 확인합니다
+func (s *Subscription) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
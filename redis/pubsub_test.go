@@ -0,0 +1,223 @@
+package redis
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dropProxy는 Redis 연결을 중계하다가 원할 때 모든 연결을 끊는 TCP 프록시입니다
+type dropProxy struct {
+	listener net.Listener
+	target   string
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func newDropProxy(t *testing.T, target string) *dropProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return newProxy(t, listener, target)
+}
+
+// newProxy는 listener로 받은 연결을 target으로 중계합니다
+func newProxy(t *testing.T, listener net.Listener, target string) *dropProxy {
+	p := &dropProxy{listener: listener, target: target}
+	go p.serve()
+	t.Cleanup(func() {
+		listener.Close()
+		p.drop()
+	})
+	return p
+}
+
+func (p *dropProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+func (p *dropProxy) serve() {
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		server, err := net.Dial("tcp", p.target)
+		if err != nil {
+			client.Close()
+			continue
+		}
+
+		p.mu.Lock()
+		p.conns = append(p.conns, client, server)
+		p.mu.Unlock()
+
+		go func() { _, _ = io.Copy(server, client); server.Close() }()
+		go func() { _, _ = io.Copy(client, server); client.Close() }()
+	}
+}
+
+// drop은 지금까지 중계한 모든 연결을 끊습니다
+func (p *dropProxy) drop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		conn.Close()
+	}
+	p.conns = nil
+}
+
+// receive는 timeout 안에 메시지 하나를 받습니다
+func receive(t *testing.T, sub *Subscription) Message {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.Channel():
+		require.True(t, ok, "subscription closed")
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+		return Message{}
+	}
+}
+
+func TestRedisPublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	sub, err := client.Subscribe(ctx, []string{"news", "alerts"})
+	require.NoError(t, err)
+	psub, err := client.PSubscribe(ctx, []string{"news:*"})
+	require.NoError(t, err)
+
+	// 구독이 확인된 뒤 반환되므로 바로 발행해도 받음
+	n, err := client.Publish(ctx, "news", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, Message{Channel: "news", Payload: "hello"}, receive(t, sub))
+
+	_, err = client.Publish(ctx, "alerts", "fire")
+	require.NoError(t, err)
+	assert.Equal(t, Message{Channel: "alerts", Payload: "fire"}, receive(t, sub))
+
+	// 패턴 구독은 일치한 패턴을 함께 전달
+	_, err = client.Publish(ctx, "news:sports", "goal")
+	require.NoError(t, err)
+	assert.Equal(t, Message{Channel: "news:sports", Pattern: "news:*", Payload: "goal"}, receive(t, psub))
+
+	// Close 후 채널이 닫힘
+	require.NoError(t, sub.Close())
+	require.NoError(t, sub.Close())
+	_, ok := <-sub.Channel()
+	assert.False(t, ok)
+	require.NoError(t, psub.Close())
+
+	assert.Eventually(t, func() bool {
+		n, err := client.Publish(ctx, "news", "anyone?")
+		return err == nil && n == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestRedisSubscribeFunc(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	type invalidation struct {
+		Keys []string `json:"keys"`
+	}
+
+	received := make(chan invalidation, 1)
+	decodeErrors := make(chan error, 1)
+	sub, err := SubscribeFunc(ctx, client, []string{"cache:invalidate"}, func(ctx context.Context, channel string, v invalidation) {
+		assert.Equal(t, "cache:invalidate", channel)
+		received <- v
+	}, WithSubscribeErrorHandler(func(err error) { decodeErrors <- err }))
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// 디코딩할 수 없는 메시지는 건너뛰고 에러 핸들러로 전달
+	_, err = client.Publish(ctx, "cache:invalidate", "not json")
+	require.NoError(t, err)
+	select {
+	case err := <-decodeErrors:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("decode error not reported")
+	}
+
+	_, err = PublishValue(ctx, client, "cache:invalidate", invalidation{Keys: []string{"user:1", "user:2"}})
+	require.NoError(t, err)
+	select {
+	case v := <-received:
+		assert.Equal(t, []string{"user:1", "user:2"}, v.Keys)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestRedisSubscribeContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sub, err := testClient.Subscribe(ctx, []string{"cancel:me"})
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case _, ok := <-sub.Channel():
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription not closed after cancel")
+	}
+	require.NoError(t, sub.Close())
+}
+
+func TestRedisSubscribeReconnect(t *testing.T) {
+	ctx := context.Background()
+
+	// 프록시를 거쳐 연결한 클라이언트
+	proxy := newDropProxy(t, testEndpoint)
+	client := NewClient(proxy.Addr())
+	defer client.Close()
+
+	var resubscribed atomic.Int32
+	var connErrors atomic.Int32
+	sub, err := client.Subscribe(ctx, []string{"reconnect"},
+		WithHealthCheck(100*time.Millisecond),
+		WithResubscribeHandler(func() { resubscribed.Add(1) }),
+		WithSubscribeErrorHandler(func(error) { connErrors.Add(1) }),
+	)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	_, err = testClient.Publish(ctx, "reconnect", "before")
+	require.NoError(t, err)
+	assert.Equal(t, "before", receive(t, sub).Payload)
+
+	// 연결이 끊기면 다시 연결해 같은 채널을 구독
+	proxy.drop()
+	assert.Eventually(t, func() bool { return resubscribed.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), connErrors.Load())
+
+	_, err = testClient.Publish(ctx, "reconnect", "after")
+	require.NoError(t, err)
+	assert.Equal(t, "after", receive(t, sub).Payload)
+}
+
+func TestRedisSubscribeUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := NewClient(addr)
+	defer client.Close()
+
+	_, err = client.Subscribe(context.Background(), []string{"nowhere"})
+	assert.ErrorIs(t, err, ErrConnection)
+}
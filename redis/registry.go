@@ -0,0 +1,151 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrUnknownScript는 등록되지 않은 이름의 스크립트를 찾을 때 반환됩니다
+	ErrUnknownScript = errors.New("unknown script")
+	// ErrDuplicateScript는 이미 등록된 이름으로 스크립트를 등록할 때 반환됩니다
+	ErrDuplicateScript = errors.New("duplicate script")
+)
+
+// ScriptRegistry는 이름으로 찾을 수 있는 Lua 스크립트 모음입니다
+//
+// WithScripts로 New에 전달하면 새 연결을 맺을 때마다(시작 시와 재연결 후) 등록된
+// 스크립트를 SCRIPT LOAD로 미리 올려 두므로, 서버 재시작이나 장애 조치 뒤에도
+// 첫 EVALSHA가 NOSCRIPT로 한 번 더 왕복하지 않습니다
+type ScriptRegistry struct {
+	mu      sync.RWMutex
+	scripts map[string]*Script
+}
+
+// NewScriptRegistry는 빈 ScriptRegistry를 생성합니다
+func NewScriptRegistry() *ScriptRegistry {
+	return &ScriptRegistry{scripts: make(map[string]*Script)}
+}
+
+// LoadScripts는 fsys의 dir 디렉터리에 있는 *.lua 파일을 파일 이름(확장자 제외)으로 등록합니다
+//
+// embed.FS와 함께 사용합니다:
+//
+//	//go:embed scripts/*.lua
+//	var scriptFiles embed.FS
+//
+//	var scripts = redis.MustLoadScripts(scriptFiles, "scripts")
+func LoadScripts(fsys fs.FS, dir string) (*ScriptRegistry, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.lua"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewScriptRegistry()
+	for _, file := range files {
+		src, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.Register(strings.TrimSuffix(path.Base(file), ".lua"), string(src)); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// MustLoadScripts는 LoadScripts와 같지만 실패하면 panic합니다 (패키지 변수 초기화용)
+func MustLoadScripts(fsys fs.FS, dir string) *ScriptRegistry {
+	r, err := LoadScripts(fsys, dir)
+	if err != nil {
+		panic(fmt.Sprintf("redis: load scripts: %v", err))
+	}
+	return r
+}
+
+// Register는 src를 name으로 등록하고 Script를 반환합니다
+func (r *ScriptRegistry) Register(name, src string) (*Script, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.scripts[name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateScript, name)
+	}
+	s := NewScript(src)
+	s.name = name
+	r.scripts[name] = s
+	return s, nil
+}
+
+// Get은 name으로 등록된 Script를 반환합니다 (없으면 ErrUnknownScript)
+func (r *ScriptRegistry) Get(name string) (*Script, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.scripts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScript, name)
+	}
+	return s, nil
+}
+
+// MustGet은 Get과 같지만 등록되지 않았으면 panic합니다
+func (r *ScriptRegistry) MustGet(name string) *Script {
+	s, err := r.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Scripts는 등록된 스크립트를 이름 순서로 반환합니다
+func (r *ScriptRegistry) Scripts() []*Script {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scripts := make([]*Script, 0, len(r.scripts))
+	for _, s := range r.scripts {
+		scripts = append(scripts, s)
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].name < scripts[j].name
+	})
+	return scripts
+}
+
+// Load는 등록된 모든 스크립트를 SCRIPT LOAD로 서버에 올립니다 (Cluster에서는 모든 마스터)
+//
+// 문법 오류가 있는 스크립트를 시작할 때 찾아내려면 New 직후에 호출합니다
+func (r *ScriptRegistry) Load(ctx context.Context, c *Client) error {
+	for _, s := range r.Scripts() {
+		if err := s.script.Load(ctx, c.rdb).Err(); err != nil {
+			return fmt.Errorf("load script %s: %w", s.name, mapScriptError(err))
+		}
+	}
+	return nil
+}
+
+// onConnect는 새 연결에서 등록된 스크립트를 한 번의 파이프라인으로 올립니다
+//
+// 올리지 못해도 RunScript가 NOSCRIPT를 받으면 다시 올리므로 연결은 실패시키지 않습니다
+func (r *ScriptRegistry) onConnect(ctx context.Context, cn *redis.Conn) error {
+	scripts := r.Scripts()
+	if len(scripts) == 0 {
+		return nil
+	}
+	_, _ = cn.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, s := range scripts {
+			s.script.Load(ctx, pipe)
+		}
+		return nil
+	})
+	return nil
+}
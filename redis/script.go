@@ -0,0 +1,178 @@
+package redis
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrScriptResult는 스크립트 결과를 요청한 타입으로 변환할 수 없을 때 반환됩니다
+var ErrScriptResult = errors.New("unsupported script result")
+
+// Script는 Redis에서 실행할 Lua 스크립트입니다
+type Script struct {
+	name   string
+	script *redis.Script
+}
+
+// NewScript는 Lua 소스로 Script를 생성합니다
+func NewScript(src string) *Script {
+	return &Script{script: redis.NewScript(src)}
+}
+
+// Name은 ScriptRegistry에 등록된 이름을 반환합니다 (NewScript로 만들었으면 빈 문자열)
+func (s *Script) Name() string {
+	return s.name
+}
+
+// Hash는 스크립트의 SHA1 해시를 반환합니다
+func (s *Script) Hash() string {
+	return s.script.Hash()
+}
+
+// RunScript는 EVALSHA로 스크립트를 실행합니다
+//
+// 서버에 캐시되어 있지 않으면(NOSCRIPT) SCRIPT LOAD로 올린 뒤 EVALSHA를 다시 실행합니다.
+// 스크립트가 반환한 에러(redis.error_reply 등)는 ErrScript로 분류합니다
+func (c *Client) RunScript(ctx context.Context, s *Script, keys []string, args ...interface{}) (interface{}, error) {
+	keys = c.keys(keys)
+	v, err := s.script.EvalSha(ctx, c.rdb, keys, args...).Result()
+	if redis.HasErrorPrefix(err, "NOSCRIPT") {
+		if err := s.script.Load(ctx, c.rdb).Err(); err != nil {
+			return nil, mapScriptError(err)
+		}
+		v, err = s.script.EvalSha(ctx, c.rdb, keys, args...).Result()
+	}
+	return v, mapScriptError(err)
+}
+
+// RunScriptValue는 인자를 변환해 스크립트를 실행하고 결과를 T로 변환합니다
+//
+// 문자열, 숫자, bool, []byte는 그대로 전달하고, time.Duration은 밀리초, time.Time은 Unix
+// 밀리초로, 그 밖의 값(구조체, 맵, 슬라이스 등)은 클라이언트의 Codec으로 인코딩해 전달합니다.
+// 결과는 string, int64, int, float64, bool, []string, []int64, interface{}로 변환하며,
+// 그 밖의 T는 스크립트가 반환한 문자열을 Codec으로 디코딩합니다 (cjson.encode 결과 등).
+// bool은 Lua의 false(nil 응답)를 false로 받고, 그 밖의 nil 응답은 ErrNotFound입니다
+func RunScriptValue[T any](ctx context.Context, c *Client, s *Script, keys []string, args ...interface{}) (T, error) {
+	var zero T
+
+	encoded := make([]interface{}, len(args))
+	for i, arg := range args {
+		v, err := c.scriptArg(arg)
+		if err != nil {
+			return zero, fmt.Errorf("script arg %d: %w", i, err)
+		}
+		encoded[i] = v
+	}
+
+	reply, err := c.RunScript(ctx, s, keys, encoded...)
+	if _, ok := any(zero).(bool); ok && errors.Is(err, ErrNotFound) {
+		return zero, nil
+	}
+	if err != nil {
+		return zero, err
+	}
+	return scriptResult[T](c, reply)
+}
+
+// scriptArg는 스크립트 인자를 Redis에 전달할 값으로 변환합니다
+func (c *Client) scriptArg(arg interface{}) (interface{}, error) {
+	switch v := arg.(type) {
+	case nil, string, []byte, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64, encoding.BinaryMarshaler:
+		return v, nil
+	case time.Duration:
+		return v.Milliseconds(), nil
+	case time.Time:
+		return v.UnixMilli(), nil
+	}
+	return c.codec.Marshal(arg)
+}
+
+// scriptResult는 스크립트 결과를 T로 변환합니다
+func scriptResult[T any](c *Client, reply interface{}) (T, error) {
+	var v T
+	var err error
+
+	switch dst := any(&v).(type) {
+	case *interface{}:
+		*dst = reply
+	case *string:
+		*dst, err = replyString(reply)
+	case *int64:
+		*dst, err = replyInt(reply)
+	case *int:
+		var n int64
+		n, err = replyInt(reply)
+		*dst = int(n)
+	case *float64:
+		*dst, err = replyFloat(reply)
+	case *bool:
+		var n int64
+		n, err = replyInt(reply)
+		*dst = n != 0
+	case *[]string:
+		*dst, err = replySlice(reply, replyString)
+	case *[]int64:
+		*dst, err = replySlice(reply, replyInt)
+	default:
+		var s string
+		if s, err = replyString(reply); err == nil {
+			err = c.codec.Unmarshal([]byte(s), &v)
+		}
+	}
+	return v, err
+}
+
+func replyString(reply interface{}) (string, error) {
+	switch r := reply.(type) {
+	case string:
+		return r, nil
+	case int64:
+		return strconv.FormatInt(r, 10), nil
+	}
+	return "", fmt.Errorf("%w: %T as string", ErrScriptResult, reply)
+}
+
+func replyInt(reply interface{}) (int64, error) {
+	switch r := reply.(type) {
+	case int64:
+		return r, nil
+	case string:
+		return strconv.ParseInt(r, 10, 64)
+	}
+	return 0, fmt.Errorf("%w: %T as integer", ErrScriptResult, reply)
+}
+
+func replyFloat(reply interface{}) (float64, error) {
+	switch r := reply.(type) {
+	case int64:
+		return float64(r), nil
+	case string:
+		return strconv.ParseFloat(r, 64)
+	}
+	return 0, fmt.Errorf("%w: %T as float", ErrScriptResult, reply)
+}
+
+func replySlice[E any](reply interface{}, convert func(interface{}) (E, error)) ([]E, error) {
+	items, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %T as slice", ErrScriptResult, reply)
+	}
+	out := make([]E, len(items))
+	for i, item := range items {
+		v, err := convert(item)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
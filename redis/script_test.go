@@ -0,0 +1,172 @@
+package redis
+
+import (
+	"context"
+	"embed"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/scripts/*.lua
+var testScriptFiles embed.FS
+
+func TestScriptRegistry(t *testing.T) {
+	registry, err := LoadScripts(testScriptFiles, "testdata/scripts")
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range registry.Scripts() {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{"has", "incr_ttl", "merge", "swap"}, names)
+
+	swap, err := registry.Get("swap")
+	require.NoError(t, err)
+	assert.Len(t, swap.Hash(), 40)
+
+	_, err = registry.Get("missing")
+	assert.ErrorIs(t, err, ErrUnknownScript)
+	assert.Panics(t, func() { registry.MustGet("missing") })
+
+	_, err = registry.Register("swap", "return 1")
+	assert.ErrorIs(t, err, ErrDuplicateScript)
+
+	// *.lua가 아닌 파일은 무시
+	registry, err = LoadScripts(fstest.MapFS{
+		"lua/ping.lua":  {Data: []byte("return 'pong'")},
+		"lua/README.md": {Data: []byte("docs")},
+	}, "lua")
+	require.NoError(t, err)
+	assert.Len(t, registry.Scripts(), 1)
+}
+
+func TestRedisScriptNoScript(t *testing.T) {
+	ctx := context.Background()
+	admin := goredis.NewClient(&goredis.Options{Addr: testEndpoint})
+	defer admin.Close()
+
+	_ = testClient.Delete(ctx, "script:swap")
+	script := NewScript(`return redis.call('GETSET', KEYS[1], ARGV[1])`)
+
+	// 서버 캐시를 비운 뒤에도 SCRIPT LOAD 후 다시 실행
+	require.NoError(t, admin.ScriptFlush(ctx).Err())
+	_, err := testClient.RunScript(ctx, script, []string{"script:swap"}, "first")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	exists, err := admin.ScriptExists(ctx, script.Hash()).Result()
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true}, exists)
+
+	v, err := testClient.RunScript(ctx, script, []string{"script:swap"}, "second")
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+}
+
+func TestRedisRunScriptValue(t *testing.T) {
+	ctx := context.Background()
+	registry := MustLoadScripts(testScriptFiles, "testdata/scripts")
+	client := testClient
+
+	type profile struct {
+		Name  string `json:"name"`
+		Level int    `json:"level"`
+	}
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "script:value", "script:counter", "script:profile")
+
+	// string 결과 (이전 값이 없으면 ErrNotFound)
+	_, err := RunScriptValue[string](ctx, client, registry.MustGet("swap"), []string{"script:value"}, "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+	old, err := RunScriptValue[string](ctx, client, registry.MustGet("swap"), []string{"script:value"}, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "a", old)
+
+	// time.Duration 인자는 밀리초로 전달
+	n, err := RunScriptValue[int64](ctx, client, registry.MustGet("incr_ttl"), []string{"script:counter"}, 5, 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	ttl, err := client.rdb.PTTL(ctx, "script:counter").Result()
+	require.NoError(t, err)
+	assert.InDelta(t, float64(2*time.Second), float64(ttl), float64(500*time.Millisecond))
+
+	// bool 결과 (Lua false는 false)
+	ok, err := RunScriptValue[bool](ctx, client, registry.MustGet("has"), []string{"script:counter"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = RunScriptValue[bool](ctx, client, registry.MustGet("has"), []string{"script:missing"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 구조체 인자는 Codec으로 인코딩하고 문자열 결과는 Codec으로 디코딩
+	merged, err := RunScriptValue[profile](ctx, client, registry.MustGet("merge"), []string{"script:profile"}, profile{Name: "alice", Level: 1})
+	require.NoError(t, err)
+	assert.Equal(t, profile{Name: "alice", Level: 1}, merged)
+
+	merged, err = RunScriptValue[profile](ctx, client, registry.MustGet("merge"), []string{"script:profile"}, map[string]int{"level": 7})
+	require.NoError(t, err)
+	assert.Equal(t, profile{Name: "alice", Level: 7}, merged)
+
+	// 변환할 수 없는 결과
+	_, err = RunScriptValue[[]string](ctx, client, registry.MustGet("incr_ttl"), []string{"script:counter"}, 1, time.Second)
+	assert.ErrorIs(t, err, ErrScriptResult)
+}
+
+func TestRedisScriptPreload(t *testing.T) {
+	ctx := context.Background()
+	registry := MustLoadScripts(testScriptFiles, "testdata/scripts")
+	proxy := newDropProxy(t, testEndpoint)
+
+	admin := goredis.NewClient(&goredis.Options{Addr: testEndpoint})
+	defer admin.Close()
+
+	hashes := make([]string, 0, len(registry.Scripts()))
+	for _, s := range registry.Scripts() {
+		hashes = append(hashes, s.Hash())
+	}
+	allLoaded := func() bool {
+		exists, err := admin.ScriptExists(ctx, hashes...).Result()
+		require.NoError(t, err)
+		for _, ok := range exists {
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	require.NoError(t, admin.ScriptFlush(ctx).Err())
+	client, err := New(WithAddr(proxy.Addr()), WithScripts(registry))
+	require.NoError(t, err)
+	defer client.Close()
+
+	// 시작 시 첫 연결에서 미리 올림
+	require.NoError(t, client.Ping(ctx))
+	assert.True(t, allLoaded())
+
+	// 서버가 재시작된 것처럼 캐시를 비우고 연결을 끊으면 재연결 후 다시 올림
+	require.NoError(t, admin.ScriptFlush(ctx).Err())
+	proxy.drop()
+	require.Eventually(t, func() bool {
+		return client.Ping(ctx) == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.True(t, allLoaded())
+
+	// 명시적으로 올리기
+	require.NoError(t, admin.ScriptFlush(ctx).Err())
+	require.NoError(t, registry.Load(ctx, client))
+	assert.True(t, allLoaded())
+
+	// 문법 오류는 Load에서 발견
+	broken := NewScriptRegistry()
+	_, err = broken.Register("broken", "this is not lua")
+	require.NoError(t, err)
+	err = broken.Load(ctx, client)
+	assert.ErrorIs(t, err, ErrScript)
+	assert.ErrorContains(t, err, "broken")
+}
@@ -0,0 +1,41 @@
+package redis
+
+import "context"
+
+// SAdd는 집합에 멤버를 추가하고 새로 추가된 멤버 수를 반환합니다
+func (c *Client) SAdd(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	v, err := c.rdb.SAdd(ctx, c.key(key), members...).Result()
+	return v, mapError(err)
+}
+
+// SRem은 집합에서 멤버를 삭제하고 삭제된 멤버 수를 반환합니다
+func (c *Client) SRem(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	v, err := c.rdb.SRem(ctx, c.key(key), members...).Result()
+	return v, mapError(err)
+}
+
+// SMembers는 집합의 모든 멤버를 조회합니다 (순서는 보장되지 않음)
+func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
+	v, err := c.rdb.SMembers(ctx, c.key(key)).Result()
+	return v, mapError(err)
+}
+
+// SIsMember는 member가 집합에 있는지 확인합니다
+func (c *Client) SIsMember(ctx context.Context, key string, member interface{}) (bool, error) {
+	v, err := c.rdb.SIsMember(ctx, c.key(key), member).Result()
+	return v, mapError(err)
+}
+
+// SCard는 집합의 멤버 수를 반환합니다
+func (c *Client) SCard(ctx context.Context, key string) (int64, error) {
+	v, err := c.rdb.SCard(ctx, c.key(key)).Result()
+	return v, mapError(err)
+}
+
+// SInter는 모든 집합에 공통으로 있는 멤버를 조회합니다
+//
+// Cluster에서는 keys가 같은 슬롯에 있어야 하므로 해시 태그를 사용해야 합니다
+func (c *Client) SInter(ctx context.Context, keys ...string) ([]string, error) {
+	v, err := c.rdb.SInter(ctx, c.keys(keys)...).Result()
+	return v, mapError(err)
+}
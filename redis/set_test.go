@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisSet(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "set:{tags}:go", "set:{tags}:redis")
+
+	added, err := client.SAdd(ctx, "set:{tags}:go", "alice", "bob", "carol")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), added)
+
+	// 중복 멤버는 추가되지 않음
+	added, err = client.SAdd(ctx, "set:{tags}:go", "alice")
+	require.NoError(t, err)
+	assert.Zero(t, added)
+
+	_, err = client.SAdd(ctx, "set:{tags}:redis", "bob", "carol", "dave")
+	require.NoError(t, err)
+
+	members, err := client.SMembers(ctx, "set:{tags}:go")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"alice", "bob", "carol"}, members)
+
+	ok, err := client.SIsMember(ctx, "set:{tags}:go", "bob")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.SIsMember(ctx, "set:{tags}:go", "dave")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 교집합
+	common, err := client.SInter(ctx, "set:{tags}:go", "set:{tags}:redis")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"bob", "carol"}, common)
+
+	removed, err := client.SRem(ctx, "set:{tags}:go", "bob", "nobody")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	n, err := client.SCard(ctx, "set:{tags}:go")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// 없는 키는 빈 집합
+	members, err = client.SMembers(ctx, "set:{tags}:missing")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
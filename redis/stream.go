@@ -0,0 +1,159 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamMessage는 스트림에 저장된 메시지입니다
+type StreamMessage struct {
+	ID     string
+	Values map[string]string
+}
+
+// PendingEntry는 컨슈머 그룹에서 전달되었지만 아직 ACK되지 않은 메시지 정보입니다
+type PendingEntry struct {
+	ID       string
+	Consumer string
+	// Idle은 마지막으로 전달된 뒤 지난 시간입니다
+	Idle time.Duration
+	// Deliveries는 지금까지 전달된 횟수입니다
+	Deliveries int64
+}
+
+// XAdd는 스트림에 메시지를 추가하고 생성된 ID를 반환합니다
+func (c *Client) XAdd(ctx context.Context, stream string, values map[string]interface{}) (string, error) {
+	id, err := c.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.key(stream),
+		Values: values,
+	}).Result()
+	return id, mapError(err)
+}
+
+// XLen은 스트림의 메시지 수를 반환합니다
+func (c *Client) XLen(ctx context.Context, stream string) (int64, error) {
+	v, err := c.rdb.XLen(ctx, c.key(stream)).Result()
+	return v, mapError(err)
+}
+
+// XRange는 start부터 end까지의 메시지를 조회합니다 ("-"와 "+"는 처음과 끝)
+func (c *Client) XRange(ctx context.Context, stream, start, end string) ([]StreamMessage, error) {
+	msgs, err := c.rdb.XRange(ctx, c.key(stream), start, end).Result()
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toStreamMessages(msgs), nil
+}
+
+// XGroupCreate는 컨슈머 그룹을 생성합니다
+//
+// 스트림이 없으면 함께 만들고, 그룹이 이미 있으면 아무것도 하지 않습니다.
+// start가 "$"이면 이후에 추가되는 메시지부터, "0"이면 처음부터 읽습니다
+func (c *Client) XGroupCreate(ctx context.Context, stream, group, start string) error {
+	err := c.rdb.XGroupCreateMkStream(ctx, c.key(stream), group, start).Err()
+	if err != nil && strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil
+	}
+	return mapError(err)
+}
+
+// XReadGroup은 컨슈머 그룹으로 아직 전달되지 않은 메시지를 최대 count개 읽습니다
+//
+// block이 0보다 크면 메시지가 올 때까지 최대 block만큼 기다리고, 0이면 바로 반환합니다.
+// 읽을 메시지가 없으면 빈 슬라이스를 반환합니다
+func (c *Client) XReadGroup(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]StreamMessage, error) {
+	if block <= 0 {
+		block = -1
+	}
+	streams, err := c.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{c.key(stream), ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	var msgs []StreamMessage
+	for _, s := range streams {
+		msgs = append(msgs, toStreamMessages(s.Messages)...)
+	}
+	return msgs, nil
+}
+
+// XAck는 메시지 처리를 완료로 표시하고 ACK된 메시지 수를 반환합니다
+func (c *Client) XAck(ctx context.Context, stream, group string, ids ...string) (int64, error) {
+	v, err := c.rdb.XAck(ctx, c.key(stream), group, ids...).Result()
+	return v, mapError(err)
+}
+
+// XAutoClaim은 minIdle 이상 ACK되지 않은 메시지를 consumer에게 다시 할당합니다
+//
+// start부터 최대 count개를 확인하며, 다음 호출에 사용할 커서를 함께 반환합니다
+// (커서가 "0-0"이면 처음부터 다시 확인)
+func (c *Client) XAutoClaim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, start string, count int64) ([]StreamMessage, string, error) {
+	msgs, next, err := c.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   c.key(stream),
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    start,
+		Count:    count,
+	}).Result()
+	if err != nil {
+		return nil, "", mapError(err)
+	}
+	return toStreamMessages(msgs), next, nil
+}
+
+// XPending은 start부터 end까지의 ACK되지 않은 메시지를 최대 count개 조회합니다
+func (c *Client) XPending(ctx context.Context, stream, group, start, end string, count int64) ([]PendingEntry, error) {
+	pending, err := c.rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.key(stream),
+		Group:  group,
+		Start:  start,
+		End:    end,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	entries := make([]PendingEntry, len(pending))
+	for i, p := range pending {
+		entries[i] = PendingEntry{
+			ID:         p.ID,
+			Consumer:   p.Consumer,
+			Idle:       p.Idle,
+			Deliveries: p.RetryCount,
+		}
+	}
+	return entries, nil
+}
+
+// toStreamMessages는 go-redis 메시지를 StreamMessage로 변환합니다
+func toStreamMessages(msgs []redis.XMessage) []StreamMessage {
+	out := make([]StreamMessage, len(msgs))
+	for i, msg := range msgs {
+		values := make(map[string]string, len(msg.Values))
+		for k, v := range msg.Values {
+			if s, ok := v.(string); ok {
+				values[k] = s
+			} else {
+				values[k] = fmt.Sprint(v)
+			}
+		}
+		out[i] = StreamMessage{ID: msg.ID, Values: values}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStreams(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "stream:orders")
+
+	// 그룹 생성은 스트림이 없어도 가능하고, 두 번 호출해도 에러가 아님
+	require.NoError(t, client.XGroupCreate(ctx, "stream:orders", "billing", "$"))
+	require.NoError(t, client.XGroupCreate(ctx, "stream:orders", "billing", "$"))
+
+	id1, err := client.XAdd(ctx, "stream:orders", map[string]interface{}{"order": "1", "amount": 100})
+	require.NoError(t, err)
+	id2, err := client.XAdd(ctx, "stream:orders", map[string]interface{}{"order": "2", "amount": 250})
+	require.NoError(t, err)
+
+	n, err := client.XLen(ctx, "stream:orders")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	msgs, err := client.XRange(ctx, "stream:orders", "-", "+")
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, StreamMessage{ID: id1, Values: map[string]string{"order": "1", "amount": "100"}}, msgs[0])
+
+	// 그룹으로 읽은 메시지는 ACK 전까지 대기 목록에 남음
+	msgs, err = client.XReadGroup(ctx, "stream:orders", "billing", "worker-1", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, id2, msgs[1].ID)
+
+	pending, err := client.XPending(ctx, "stream:orders", "billing", "-", "+", 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "worker-1", pending[0].Consumer)
+	assert.Equal(t, int64(1), pending[0].Deliveries)
+
+	acked, err := client.XAck(ctx, "stream:orders", "billing", id1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), acked)
+
+	// 새 메시지가 없으면 block 동안 기다린 뒤 빈 결과
+	start := time.Now()
+	msgs, err = client.XReadGroup(ctx, "stream:orders", "billing", "worker-1", 10, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// 오래 ACK되지 않은 메시지는 다른 컨슈머가 가져갈 수 있음
+	time.Sleep(50 * time.Millisecond)
+	claimed, next, err := client.XAutoClaim(ctx, "stream:orders", "billing", "worker-2", 50*time.Millisecond, "0-0", 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, id2, claimed[0].ID)
+	assert.Equal(t, "0-0", next)
+
+	pending, err = client.XPending(ctx, "stream:orders", "billing", id2, id2, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "worker-2", pending[0].Consumer)
+	assert.Equal(t, int64(2), pending[0].Deliveries)
+}
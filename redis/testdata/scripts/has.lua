@@ -0,0 +1,2 @@
+-- KEYS[1]: 키
+return redis.call('EXISTS', KEYS[1]) == 1
@@ -0,0 +1,4 @@
+-- KEYS[1]: 카운터, ARGV[1]: 증가량, ARGV[2]: 만료 시간(ms)
+local n = redis.call('INCRBY', KEYS[1], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return n
@@ -0,0 +1,13 @@
+-- KEYS[1]: JSON 문서, ARGV[1]: 병합할 JSON 객체
+-- 병합된 문서를 반환합니다
+local doc = {}
+local raw = redis.call('GET', KEYS[1])
+if raw then
+	doc = cjson.decode(raw)
+end
+for k, v in pairs(cjson.decode(ARGV[1])) do
+	doc[k] = v
+end
+local merged = cjson.encode(doc)
+redis.call('SET', KEYS[1], merged)
+return merged
@@ -0,0 +1,5 @@
+-- KEYS[1]: 키, ARGV[1]: 새 값
+-- 이전 값을 반환합니다 (없으면 nil)
+local old = redis.call('GET', KEYS[1])
+redis.call('SET', KEYS[1], ARGV[1])
+return old
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// SetJSON은 값을 JSON으로 인코딩해 저장합니다
+func SetJSON[T any](ctx context.Context, c *Client, key string, value T, expiration time.Duration) error {
+	return setEncoded(ctx, c, JSONCodec{}, key, value, expiration)
+}
+
+// GetJSON은 JSON으로 저장된 값을 T로 디코딩해 조회합니다
+func GetJSON[T any](ctx context.Context, c *Client, key string) (T, error) {
+	return getDecoded[T](ctx, c, JSONCodec{}, key)
+}
+
+// SetValue는 클라이언트의 Codec(SetCodec 참고)으로 값을 인코딩해 저장합니다
+func SetValue[T any](ctx context.Context, c *Client, key string, value T, expiration time.Duration) error {
+	return setEncoded(ctx, c, c.codec, key, value, expiration)
+}
+
+// GetValue는 클라이언트의 Codec으로 저장된 값을 T로 디코딩해 조회합니다
+func GetValue[T any](ctx context.Context, c *Client, key string) (T, error) {
+	return getDecoded[T](ctx, c, c.codec, key)
+}
+
+func setEncoded(ctx context.Context, c *Client, codec Codec, key string, value interface{}, expiration time.Duration) error {
+	data, err := codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return mapError(c.rdb.Set(ctx, c.key(key), data, expiration).Err())
+}
+
+func getDecoded[T any](ctx context.Context, c *Client, codec Codec, key string) (T, error) {
+	var value T
+
+	data, err := c.rdb.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		return value, mapError(err)
+	}
+
+	err = codec.Unmarshal(data, &value)
+	return value, err
+}
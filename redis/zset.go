@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Z는 정렬된 집합(ZSET)의 멤버와 점수입니다
+type Z struct {
+	Member string
+	Score  float64
+}
+
+// ZAdd는 멤버를 점수와 함께 추가하거나 점수를 갱신하고, 새로 추가된 멤버 수를 반환합니다
+func (c *Client) ZAdd(ctx context.Context, key string, members ...Z) (int64, error) {
+	zs := make([]redis.Z, len(members))
+	for i, m := range members {
+		zs[i] = redis.Z{Score: m.Score, Member: m.Member}
+	}
+	v, err := c.rdb.ZAdd(ctx, c.key(key), zs...).Result()
+	return v, mapError(err)
+}
+
+// ZIncrBy는 멤버의 점수를 increment만큼 늘리고 새 점수를 반환합니다 (없으면 0에서 시작)
+func (c *Client) ZIncrBy(ctx context.Context, key, member string, increment float64) (float64, error) {
+	v, err := c.rdb.ZIncrBy(ctx, c.key(key), increment, member).Result()
+	return v, mapError(err)
+}
+
+// ZScore는 멤버의 점수를 반환합니다 (멤버가 없으면 ErrNotFound)
+func (c *Client) ZScore(ctx context.Context, key, member string) (float64, error) {
+	v, err := c.rdb.ZScore(ctx, c.key(key), member).Result()
+	return v, mapError(err)
+}
+
+// ZRank는 점수 오름차순에서 멤버의 순위(0부터)를 반환합니다 (멤버가 없으면 ErrNotFound)
+func (c *Client) ZRank(ctx context.Context, key, member string) (int64, error) {
+	v, err := c.rdb.ZRank(ctx, c.key(key), member).Result()
+	return v, mapError(err)
+}
+
+// ZRevRank는 점수 내림차순에서 멤버의 순위(0부터)를 반환합니다 (멤버가 없으면 ErrNotFound)
+func (c *Client) ZRevRank(ctx context.Context, key, member string) (int64, error) {
+	v, err := c.rdb.ZRevRank(ctx, c.key(key), member).Result()
+	return v, mapError(err)
+}
+
+// ZRange는 점수 오름차순으로 start부터 stop까지의 멤버를 점수와 함께 조회합니다 (음수는 끝에서부터)
+func (c *Client) ZRange(ctx context.Context, key string, start, stop int64) ([]Z, error) {
+	zs, err := c.rdb.ZRangeWithScores(ctx, c.key(key), start, stop).Result()
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toZ(zs), nil
+}
+
+// ZRevRange는 점수 내림차순으로 start부터 stop까지의 멤버를 점수와 함께 조회합니다
+func (c *Client) ZRevRange(ctx context.Context, key string, start, stop int64) ([]Z, error) {
+	zs, err := c.rdb.ZRevRangeWithScores(ctx, c.key(key), start, stop).Result()
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toZ(zs), nil
+}
+
+// ZRangeByScore는 점수가 min 이상 max 이하인 멤버를 오름차순으로 offset부터 최대 count개 조회합니다
+//
+// min과 max에는 "-inf", "+inf"와 "(10"(초과/미만) 형식을 사용할 수 있고, count가 0 이하이면 모두 조회합니다
+func (c *Client) ZRangeByScore(ctx context.Context, key, min, max string, offset, count int64) ([]Z, error) {
+	if count <= 0 {
+		offset, count = 0, 0
+	}
+	zs, err := c.rdb.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{
+		Key:     c.key(key),
+		Start:   min,
+		Stop:    max,
+		ByScore: true,
+		Offset:  offset,
+		Count:   count,
+	}).Result()
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return toZ(zs), nil
+}
+
+// ZRem은 멤버를 삭제하고 삭제된 멤버 수를 반환합니다
+func (c *Client) ZRem(ctx context.Context, key string, members ...string) (int64, error) {
+	args := make([]interface{}, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+	v, err := c.rdb.ZRem(ctx, c.key(key), args...).Result()
+	return v, mapError(err)
+}
+
+// ZCard는 정렬된 집합의 멤버 수를 반환합니다
+func (c *Client) ZCard(ctx context.Context, key string) (int64, error) {
+	v, err := c.rdb.ZCard(ctx, c.key(key)).Result()
+	return v, mapError(err)
+}
+
+// toZ는 go-redis의 Z를 Z로 변환합니다
+func toZ(zs []redis.Z) []Z {
+	out := make([]Z, len(zs))
+	for i, z := range zs {
+		member, ok := z.Member.(string)
+		if !ok {
+			member = fmt.Sprint(z.Member)
+		}
+		out[i] = Z{Member: member, Score: z.Score}
+	}
+	return out
+}
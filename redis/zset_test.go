@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisSortedSet(t *testing.T) {
+	ctx := context.Background()
+	client := testClient
+
+	// 테스트 전 키 정리
+	_ = client.Delete(ctx, "zset:scores")
+
+	added, err := client.ZAdd(ctx, "zset:scores", Z{Member: "alice", Score: 10}, Z{Member: "bob", Score: 20}, Z{Member: "carol", Score: 15})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), added)
+
+	// 이미 있는 멤버는 점수만 갱신
+	added, err = client.ZAdd(ctx, "zset:scores", Z{Member: "alice", Score: 12})
+	require.NoError(t, err)
+	assert.Zero(t, added)
+
+	score, err := client.ZIncrBy(ctx, "zset:scores", "alice", 3.5)
+	require.NoError(t, err)
+	assert.Equal(t, 15.5, score)
+
+	score, err = client.ZScore(ctx, "zset:scores", "bob")
+	require.NoError(t, err)
+	assert.Equal(t, float64(20), score)
+
+	// 순위
+	rank, err := client.ZRank(ctx, "zset:scores", "carol")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rank)
+
+	rank, err = client.ZRevRank(ctx, "zset:scores", "bob")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rank)
+
+	_, err = client.ZRank(ctx, "zset:scores", "nobody")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = client.ZScore(ctx, "zset:scores", "nobody")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 범위 조회
+	members, err := client.ZRange(ctx, "zset:scores", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []Z{{"carol", 15}, {"alice", 15.5}, {"bob", 20}}, members)
+
+	members, err = client.ZRevRange(ctx, "zset:scores", 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Z{{"bob", 20}, {"alice", 15.5}}, members)
+
+	members, err = client.ZRangeByScore(ctx, "zset:scores", "(15", "+inf", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []Z{{"alice", 15.5}, {"bob", 20}}, members)
+
+	members, err = client.ZRangeByScore(ctx, "zset:scores", "-inf", "+inf", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Z{{"alice", 15.5}}, members)
+
+	// 삭제
+	removed, err := client.ZRem(ctx, "zset:scores", "alice", "nobody")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	n, err := client.ZCard(ctx, "zset:scores")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
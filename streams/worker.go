@@ -0,0 +1,376 @@
+// Package streams는 Redis Streams 컨슈머 그룹으로 메시지를 처리하는 워커를 제공합니다
+//
+// 워커는 XREADGROUP으로 새 메시지를 읽어 핸들러를 동시에 여러 개 실행하고, 성공한 메시지만
+// ACK합니다. 다른 컨슈머가 죽어 오래 ACK되지 않은 메시지는 XAUTOCLAIM으로 가져와 다시 처리하며,
+// 지정한 횟수만큼 전달되어도 처리하지 못한 메시지는 데드 레터 스트림으로 옮깁니다
+package streams
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"testcontainers-learning/redis"
+)
+
+// 기본 설정값
+const (
+	DefaultConcurrency   = 1
+	DefaultBatchSize     = 10
+	DefaultBlock         = 5 * time.Second
+	DefaultClaimIdle     = 30 * time.Second
+	DefaultMaxDeliveries = 5
+)
+
+// errorBackoff는 스트림 읽기에 실패했을 때 다시 시도하기 전 대기 시간입니다
+const errorBackoff = 500 * time.Millisecond
+
+// ErrMaxDeliveries는 핸들러를 실행하기 전에 이미 최대 전달 횟수를 넘은 메시지의 데드 레터 사유입니다
+var ErrMaxDeliveries = errors.New("message exceeded max deliveries")
+
+// 데드 레터 메시지에 원본 필드와 함께 기록하는 필드 이름
+const (
+	FieldOriginalID = "dlq:id"
+	FieldDeliveries = "dlq:deliveries"
+	FieldError      = "dlq:error"
+	FieldConsumer   = "dlq:consumer"
+)
+
+// Handler는 메시지 하나를 처리합니다
+//
+// nil을 반환하면 메시지가 ACK되고, 에러를 반환하면 ACK되지 않아 나중에 다시 전달됩니다
+type Handler func(ctx context.Context, msg redis.StreamMessage) error
+
+// config는 Worker 설정입니다
+type config struct {
+	consumer      string
+	concurrency   int
+	batchSize     int
+	block         time.Duration
+	startID       string
+	claimIdle     time.Duration
+	claimInterval time.Duration
+	maxDeliveries int64
+	deadLetter    string
+	onError       func(error)
+}
+
+// Option은 Worker 동작을 설정합니다
+type Option func(*config)
+
+// WithConsumer는 컨슈머 이름을 설정합니다 (기본값은 호스트 이름과 임의의 접미사)
+func WithConsumer(name string) Option {
+	return func(c *config) {
+		c.consumer = name
+	}
+}
+
+// WithConcurrency는 동시에 실행할 핸들러 수를 설정합니다 (기본값 DefaultConcurrency)
+func WithConcurrency(n int) Option {
+	return func(c *config) {
+		c.concurrency = n
+	}
+}
+
+// WithBatchSize는 XREADGROUP 한 번에 읽을 최대 메시지 수를 설정합니다 (기본값 DefaultBatchSize)
+//
+// 비어 있는 핸들러 수보다 많이 읽지는 않습니다
+func WithBatchSize(n int) Option {
+	return func(c *config) {
+		c.batchSize = n
+	}
+}
+
+// WithBlock은 새 메시지를 기다리는 최대 시간을 설정합니다 (기본값 DefaultBlock)
+func WithBlock(d time.Duration) Option {
+	return func(c *config) {
+		c.block = d
+	}
+}
+
+// WithStartID는 컨슈머 그룹이 없을 때 그룹을 만들 시작 위치를 설정합니다
+//
+// 기본값 "$"는 이후에 추가되는 메시지부터, "0"은 스트림의 처음부터 처리합니다
+func WithStartID(id string) Option {
+	return func(c *config) {
+		c.startID = id
+	}
+}
+
+// WithClaim은 minIdle 이상 ACK되지 않은 메시지를 interval마다 가져와 다시 처리하도록 설정합니다
+//
+// 기본값은 DefaultClaimIdle이며 interval이 0 이하이면 minIdle의 절반입니다
+func WithClaim(minIdle, interval time.Duration) Option {
+	return func(c *config) {
+		c.claimIdle = minIdle
+		c.claimInterval = interval
+	}
+}
+
+// WithDeadLetter는 n번 전달되어도 처리하지 못한 메시지를 stream으로 옮기도록 설정합니다
+//
+// 기본값은 DefaultMaxDeliveries번, 원본 스트림 이름에 ":dead"를 붙인 스트림입니다.
+// n이 0 이하이면 메시지를 옮기지 않고 계속 다시 시도합니다
+func WithDeadLetter(stream string, n int64) Option {
+	return func(c *config) {
+		c.deadLetter = stream
+		c.maxDeliveries = n
+	}
+}
+
+// WithErrorHandler는 핸들러 실패와 스트림 읽기 에러를 받을 함수를 설정합니다
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Worker는 컨슈머 그룹의 메시지를 핸들러로 처리합니다
+type Worker struct {
+	client  *redis.Client
+	stream  string
+	group   string
+	handler Handler
+	cfg     config
+
+	// sem은 실행 중인 핸들러 수만큼 채워집니다
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+// NewWorker는 stream의 group을 처리하는 Worker를 생성합니다
+func NewWorker(client *redis.Client, stream, group string, handler Handler, opts ...Option) *Worker {
+	cfg := config{
+		concurrency:   DefaultConcurrency,
+		batchSize:     DefaultBatchSize,
+		block:         DefaultBlock,
+		startID:       "$",
+		claimIdle:     DefaultClaimIdle,
+		maxDeliveries: DefaultMaxDeliveries,
+		deadLetter:    stream + ":dead",
+		onError:       func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.consumer == "" {
+		cfg.consumer = defaultConsumer()
+	}
+	cfg.concurrency = max(cfg.concurrency, 1)
+	cfg.batchSize = max(cfg.batchSize, 1)
+	if cfg.claimInterval <= 0 {
+		cfg.claimInterval = max(cfg.claimIdle/2, time.Millisecond)
+	}
+
+	return &Worker{
+		client:  client,
+		stream:  stream,
+		group:   group,
+		handler: handler,
+		cfg:     cfg,
+		sem:     make(chan struct{}, cfg.concurrency),
+	}
+}
+
+// Consumer는 컨슈머 이름을 반환합니다
+func (w *Worker) Consumer() string {
+	return w.cfg.consumer
+}
+
+// Run은 ctx가 취소될 때까지 메시지를 처리합니다
+//
+// 컨슈머 그룹이 없으면 먼저 만듭니다. ctx가 취소되면 새 메시지를 읽지 않고
+// 실행 중인 핸들러가 끝날 때까지 기다린 뒤 nil을 반환합니다. 핸들러에는 취소되지 않는
+// ctx가 전달되므로 종료 중에도 처리 중인 메시지를 끝낼 수 있습니다
+func (w *Worker) Run(ctx context.Context) error {
+	if err := w.client.XGroupCreate(ctx, w.stream, w.group, w.cfg.startID); err != nil {
+		return fmt.Errorf("streams: create group %s: %w", w.group, err)
+	}
+
+	handlerCtx := context.WithoutCancel(ctx)
+	defer w.wg.Wait()
+
+	cursor := "0-0"
+	nextClaim := time.Now()
+	for {
+		n := w.acquire(ctx, w.cfg.batchSize)
+		if n == 0 {
+			return nil
+		}
+
+		// 오래 ACK되지 않은 메시지를 새 메시지보다 먼저 가져옴
+		if !time.Now().Before(nextClaim) {
+			claimed, err := w.claim(ctx, handlerCtx, &cursor, n)
+			nextClaim = time.Now().Add(w.cfg.claimInterval)
+			if err != nil && ctx.Err() == nil {
+				w.cfg.onError(err)
+			}
+			if n -= claimed; n == 0 {
+				continue
+			}
+		}
+
+		// 다음 회수 시점이 지나지 않도록 기다리는 시간을 제한
+		block := max(min(w.cfg.block, time.Until(nextClaim)), time.Millisecond)
+		msgs, err := w.client.XReadGroup(ctx, w.stream, w.group, w.cfg.consumer, int64(n), block)
+		w.release(n - len(msgs))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			w.cfg.onError(fmt.Errorf("streams: read %s: %w", w.stream, err))
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(errorBackoff):
+			}
+			continue
+		}
+
+		for _, msg := range msgs {
+			w.dispatch(handlerCtx, msg, 1)
+		}
+	}
+}
+
+// claim은 오래 ACK되지 않은 메시지를 최대 n개 가져와 처리를 시작하고 가져온 수를 반환합니다
+//
+// 가져오지 못한 자리는 반납하지 않으므로 호출자가 n에서 반환값을 뺀 만큼 계속 사용합니다
+func (w *Worker) claim(ctx, handlerCtx context.Context, cursor *string, n int) (int, error) {
+	msgs, next, err := w.client.XAutoClaim(ctx, w.stream, w.group, w.cfg.consumer, w.cfg.claimIdle, *cursor, int64(n))
+	if err != nil {
+		return 0, fmt.Errorf("streams: claim %s: %w", w.stream, err)
+	}
+	*cursor = next
+
+	for _, msg := range msgs {
+		w.dispatch(handlerCtx, msg, w.deliveries(ctx, msg.ID))
+	}
+	return len(msgs), nil
+}
+
+// deliveries는 메시지가 지금까지 전달된 횟수를 조회합니다 (조회에 실패하면 0)
+func (w *Worker) deliveries(ctx context.Context, id string) int64 {
+	entries, err := w.client.XPending(ctx, w.stream, w.group, id, id, 1)
+	if err != nil || len(entries) == 0 {
+		return 0
+	}
+	return entries[0].Deliveries
+}
+
+// acquire는 비어 있는 핸들러 자리를 최대 n개 확보하고 확보한 수를 반환합니다
+//
+// ctx가 취소되지 않는 한 적어도 하나를 기다립니다
+func (w *Worker) acquire(ctx context.Context, n int) int {
+	got := 0
+	select {
+	case w.sem <- struct{}{}:
+		got++
+	case <-ctx.Done():
+		return 0
+	}
+	for got < n {
+		select {
+		case w.sem <- struct{}{}:
+			got++
+		default:
+			return got
+		}
+	}
+	return got
+}
+
+// release는 확보한 핸들러 자리 n개를 반납합니다
+func (w *Worker) release(n int) {
+	for i := 0; i < n; i++ {
+		<-w.sem
+	}
+}
+
+// dispatch는 확보해 둔 자리에서 메시지를 처리합니다
+func (w *Worker) dispatch(ctx context.Context, msg redis.StreamMessage, deliveries int64) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer w.release(1)
+		w.process(ctx, msg, deliveries)
+	}()
+}
+
+// process는 핸들러를 실행하고 결과에 따라 ACK하거나 데드 레터로 옮깁니다
+func (w *Worker) process(ctx context.Context, msg redis.StreamMessage, deliveries int64) {
+	limited := w.cfg.maxDeliveries > 0
+	if limited && deliveries > w.cfg.maxDeliveries {
+		w.deadLetter(ctx, msg, deliveries, ErrMaxDeliveries)
+		return
+	}
+
+	err := w.handle(ctx, msg)
+	switch {
+	case err == nil:
+		if _, err := w.client.XAck(ctx, w.stream, w.group, msg.ID); err != nil {
+			w.cfg.onError(fmt.Errorf("streams: ack %s: %w", msg.ID, err))
+		}
+	case limited && deliveries >= w.cfg.maxDeliveries:
+		w.deadLetter(ctx, msg, deliveries, err)
+	default:
+		w.cfg.onError(fmt.Errorf("streams: handle %s (delivery %d): %w", msg.ID, deliveries, err))
+	}
+}
+
+// handle은 핸들러를 실행하고 panic을 에러로 바꿉니다
+func (w *Worker) handle(ctx context.Context, msg redis.StreamMessage) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panic: %v", p)
+		}
+	}()
+	return w.handler(ctx, msg)
+}
+
+// deadLetter는 메시지를 데드 레터 스트림에 추가하고 원본 메시지를 ACK합니다
+func (w *Worker) deadLetter(ctx context.Context, msg redis.StreamMessage, deliveries int64, cause error) {
+	args := []interface{}{w.group, msg.ID,
+		FieldOriginalID, msg.ID,
+		FieldDeliveries, deliveries,
+		FieldError, cause.Error(),
+		FieldConsumer, w.cfg.consumer,
+	}
+	for k, v := range msg.Values {
+		args = append(args, k, v)
+	}
+
+	if _, err := w.client.RunScript(ctx, deadLetterScript, []string{w.stream, w.cfg.deadLetter}, args...); err != nil {
+		w.cfg.onError(fmt.Errorf("streams: dead letter %s: %w", msg.ID, err))
+		return
+	}
+	w.cfg.onError(fmt.Errorf("streams: moved %s to %s after %d deliveries: %w", msg.ID, w.cfg.deadLetter, deliveries, cause))
+}
+
+// deadLetterScript는 메시지를 데드 레터 스트림에 추가하고 원본을 ACK합니다
+//
+// KEYS[1]: 원본 스트림, KEYS[2]: 데드 레터 스트림, ARGV: 그룹, ID, 필드/값 목록
+var deadLetterScript = redis.NewScript(`
+redis.call('XADD', KEYS[2], '*', unpack(ARGV, 3))
+return redis.call('XACK', KEYS[1], ARGV[1], ARGV[2])
+`)
+
+// defaultConsumer는 호스트 이름과 임의의 접미사로 컨슈머 이름을 만듭니다
+func defaultConsumer() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "consumer"
+	}
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return host + "-" + strconv.Itoa(os.Getpid())
+	}
+	return host + "-" + hex.EncodeToString(b)
+}
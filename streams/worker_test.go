@@ -0,0 +1,288 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"testcontainers-learning/redis"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Redis 컨테이너 시작 (모든 테스트에서 공유)
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	if err != nil {
+		panic(err)
+	}
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		_ = testcontainers.TerminateContainer(redisContainer)
+		panic(err)
+	}
+	testClient = redis.NewClient(endpoint)
+
+	// 테스트 실행
+	code := m.Run()
+
+	// 정리
+	testClient.Close()
+	if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+		panic(err)
+	}
+
+	os.Exit(code)
+}
+
+// newStream은 테스트 이름으로 비어 있는 스트림 이름을 만듭니다
+func newStream(t *testing.T) string {
+	stream := "stream:" + t.Name()
+	_ = testClient.Delete(context.Background(), stream, stream+":dead")
+	return stream
+}
+
+// startWorker는 stream을 처리하는 Worker를 백그라운드에서 실행합니다
+//
+// 반환된 함수는 Worker를 멈추고 Run이 끝날 때까지 기다립니다
+func startWorker(t *testing.T, stream string, handler Handler, opts ...Option) func() {
+	opts = append([]Option{WithStartID("0"), WithBlock(50 * time.Millisecond)}, opts...)
+	w := NewWorker(testClient, stream, "workers", handler, opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			require.NoError(t, <-done)
+		})
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// add는 스트림에 메시지를 추가합니다
+func add(t *testing.T, stream string, values map[string]interface{}) string {
+	id, err := testClient.XAdd(context.Background(), stream, values)
+	require.NoError(t, err)
+	return id
+}
+
+// pendingCount는 ACK되지 않은 메시지 수를 반환합니다
+func pendingCount(t *testing.T, stream string) int {
+	pending, err := testClient.XPending(context.Background(), stream, "workers", "-", "+", 100)
+	require.NoError(t, err)
+	return len(pending)
+}
+
+func TestWorkerProcessesMessages(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]int)
+
+	stream := newStream(t)
+	stop := startWorker(t, stream, func(ctx context.Context, msg redis.StreamMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[msg.Values["n"]]++
+		return nil
+	}, WithConcurrency(4))
+
+	for i := 0; i < 20; i++ {
+		add(t, stream, map[string]interface{}{"n": i})
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen) == 20
+	}, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	// 모든 메시지가 정확히 한 번 처리되고 ACK됨
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, 1, seen[fmt.Sprint(i)], i)
+	}
+	assert.Zero(t, pendingCount(t, stream))
+}
+
+func TestWorkerConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	var handled atomic.Int32
+
+	stream := newStream(t)
+	stop := startWorker(t, stream, func(ctx context.Context, msg redis.StreamMessage) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		running.Add(-1)
+		handled.Add(1)
+		return nil
+	}, WithConcurrency(3), WithBatchSize(10))
+
+	for i := 0; i < 12; i++ {
+		add(t, stream, map[string]interface{}{"n": i})
+	}
+
+	assert.Eventually(t, func() bool { return handled.Load() == 12 }, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	// 동시에 실행되는 핸들러 수는 설정값을 넘지 않음
+	assert.Equal(t, int32(3), peak.Load())
+}
+
+func TestWorkerReclaimsFromCrashedConsumer(t *testing.T) {
+	ctx := context.Background()
+	stream := newStream(t)
+
+	// 메시지를 읽기만 하고 ACK하지 않은 채 죽은 컨슈머
+	require.NoError(t, testClient.XGroupCreate(ctx, stream, "workers", "0"))
+	id := add(t, stream, map[string]interface{}{"job": "resize"})
+	msgs, err := testClient.XReadGroup(ctx, stream, "workers", "crashed", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	var mu sync.Mutex
+	var got []string
+	stop := startWorker(t, stream, func(ctx context.Context, msg redis.StreamMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, msg.ID)
+		return nil
+	}, WithConsumer("survivor"), WithClaim(100*time.Millisecond, 20*time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	assert.Equal(t, []string{id}, got)
+	assert.Zero(t, pendingCount(t, stream))
+}
+
+func TestWorkerDeadLetter(t *testing.T) {
+	ctx := context.Background()
+
+	var attempts atomic.Int32
+	var errs atomic.Int32
+	stream := newStream(t)
+	stop := startWorker(t, stream, func(ctx context.Context, msg redis.StreamMessage) error {
+		if msg.Values["kind"] == "poison" {
+			attempts.Add(1)
+			return errors.New("cannot parse payload")
+		}
+		return nil
+	},
+		WithClaim(50*time.Millisecond, 10*time.Millisecond),
+		WithDeadLetter(stream+":dead", 3),
+		WithErrorHandler(func(error) { errs.Add(1) }),
+	)
+
+	poison := add(t, stream, map[string]interface{}{"kind": "poison", "payload": "{"})
+	add(t, stream, map[string]interface{}{"kind": "ok"})
+
+	dead := stream + ":dead"
+	assert.Eventually(t, func() bool {
+		n, err := testClient.XLen(ctx, dead)
+		return err == nil && n == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	// 정확히 3번 시도한 뒤 옮겨지고 원본은 ACK됨
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Equal(t, int32(3), errs.Load())
+	assert.Zero(t, pendingCount(t, stream))
+
+	msgs, err := testClient.XRange(ctx, dead, "-", "+")
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, poison, msgs[0].Values[FieldOriginalID])
+	assert.Equal(t, "3", msgs[0].Values[FieldDeliveries])
+	assert.Equal(t, "cannot parse payload", msgs[0].Values[FieldError])
+	assert.Equal(t, "poison", msgs[0].Values["kind"])
+	assert.Equal(t, "{", msgs[0].Values["payload"])
+}
+
+func TestWorkerDeadLetterAfterCrashes(t *testing.T) {
+	ctx := context.Background()
+	stream := newStream(t)
+
+	// 처리 중 계속 죽어서 이미 최대 전달 횟수를 넘은 메시지
+	require.NoError(t, testClient.XGroupCreate(ctx, stream, "workers", "0"))
+	add(t, stream, map[string]interface{}{"job": "crash"})
+	_, err := testClient.XReadGroup(ctx, stream, "workers", "crashed", 10, 0)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		time.Sleep(20 * time.Millisecond)
+		_, _, err := testClient.XAutoClaim(ctx, stream, "workers", "crashed", 10*time.Millisecond, "0-0", 10)
+		require.NoError(t, err)
+	}
+
+	var handled atomic.Int32
+	stop := startWorker(t, stream, func(ctx context.Context, msg redis.StreamMessage) error {
+		handled.Add(1)
+		return nil
+	}, WithClaim(20*time.Millisecond, 10*time.Millisecond), WithDeadLetter(stream+":dead", 3))
+
+	assert.Eventually(t, func() bool {
+		n, err := testClient.XLen(ctx, stream+":dead")
+		return err == nil && n == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	// 핸들러를 실행하지 않고 바로 옮김
+	assert.Zero(t, handled.Load())
+	msgs, err := testClient.XRange(ctx, stream+":dead", "-", "+")
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, ErrMaxDeliveries.Error(), msgs[0].Values[FieldError])
+}
+
+func TestWorkerGracefulShutdown(t *testing.T) {
+	started := make(chan struct{})
+	var finished atomic.Bool
+
+	stream := newStream(t)
+	stop := startWorker(t, stream, func(ctx context.Context, msg redis.StreamMessage) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+
+		// 종료 중에도 핸들러의 ctx는 취소되지 않음
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		finished.Store(true)
+		return nil
+	})
+
+	add(t, stream, map[string]interface{}{"job": "slow"})
+	<-started
+
+	// Run은 실행 중인 핸들러가 끝날 때까지 기다림
+	stop()
+	assert.True(t, finished.Load())
+	assert.Zero(t, pendingCount(t, stream))
+}
@@ -0,0 +1,408 @@
+// Package writecache는 PostgreSQL Repository에 대한 쓰기와 Redis 캐시 갱신을 조정합니다
+//
+// write-through 모드는 DB 쓰기 직후 새 값을 캐시에 동기적으로 저장하고,
+// write-behind 모드는 캐시 갱신을 큐에 모아 두었다가 일괄로 반영합니다.
+// 두 모드 모두 DB 쓰기가 실패하거나 트랜잭션이 롤백되면 캐시를 건드리지 않습니다.
+//
+// 캐시 갱신은 트랜잭션 커밋 후에 실행되어 여러 쓰기의 순서가 커밋 순서와 다를 수 있으므로,
+// 값을 저장하려면 WithVersionedWrite로 행 버전을 지정해 더 새로운 값만 저장하도록 해야 합니다.
+// 값 대신 캐시 키를 무효화하려면 WithInvalidateOnWrite를 사용합니다
+package writecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"testcontainers-learning/cache"
+	"testcontainers-learning/postgres"
+)
+
+// write-behind 기본 설정값
+const (
+	DefaultFlushInterval = time.Second
+	DefaultBatchSize     = 100
+)
+
+// ErrCacheUpdate는 DB 쓰기는 성공했지만 캐시 갱신에 실패했을 때 반환됩니다
+//
+// 이 경우 캐시 키는 가능한 한 무효화되므로 다음 조회는 DB에서 다시 읽습니다
+var ErrCacheUpdate = errors.New("cache update failed")
+
+// ErrClosed는 Close 이후에 쓰기를 시도할 때 반환됩니다
+var ErrClosed = errors.New("coordinator closed")
+
+// ErrVersionRequired는 WithVersionedWrite와 WithInvalidateOnWrite를 모두 지정하지 않았을 때
+// New가 반환합니다
+var ErrVersionRequired = errors.New("versioned write or invalidate on write is required")
+
+// Mode는 캐시 갱신 방식입니다
+type Mode int
+
+const (
+	// WriteThrough는 DB 쓰기(트랜잭션 안이면 커밋) 직후 캐시를 동기적으로 갱신합니다
+	WriteThrough Mode = iota
+	// WriteBehind는 캐시 갱신을 큐에 모아 주기적으로 일괄 반영합니다
+	WriteBehind
+)
+
+// config는 Coordinator 설정입니다
+type config struct {
+	mode Mode
+	// version은 캐시에 저장할 값의 행 버전을 반환합니다
+	version func(any) int64
+	// invalidate가 true이면 값을 저장하는 대신 캐시 키를 무효화합니다
+	invalidate    bool
+	flushInterval time.Duration
+	batchSize     int
+	onError       func(error)
+}
+
+// Option은 Coordinator 동작을 설정합니다
+type Option func(*config)
+
+// WithWriteBehind는 write-behind 모드로 설정합니다
+//
+// 대기 중인 갱신은 interval마다, 또는 batchSize개가 쌓이면 반영되며, 그 전까지는 캐시에
+// 이전 값이 남아 있을 수 있습니다
+func WithWriteBehind(interval time.Duration, batchSize int) Option {
+	return func(c *config) {
+		c.mode = WriteBehind
+		c.flushInterval = interval
+		c.batchSize = batchSize
+	}
+}
+
+// WithInvalidateOnWrite는 쓰기 시 새 값을 저장하는 대신 캐시 키를 무효화합니다
+//
+// 다음 조회가 DB에서 다시 읽으므로 행 버전이 없어도 이전 값이 남지 않습니다.
+// 앞서 전달한 WithVersionedWrite를 취소합니다
+func WithInvalidateOnWrite() Option {
+	return func(c *config) {
+		c.invalidate = true
+		c.version = nil
+	}
+}
+
+// WithVersionedWrite는 쓰기 시 version이 캐시에 저장된 값보다 클 때만 새 값을 캐시에
+// 저장합니다 (cache.Cache.SetVersion 참고)
+//
+// version은 쓰기마다 증가하는 행 버전(버전 컬럼, 수정 시각 등)을 반환해야 하며, 같은 행에 대한
+// 두 쓰기의 커밋 순서와 버전 순서가 같아야 합니다. 캐시의 Store가 cache.VersionedStore를
+// 구현하지 않으면 갱신마다 키를 무효화하고 ErrCacheUpdate를 반환합니다.
+// 삭제는 항상 무효화로 처리하므로, 삭제보다 늦게 도착한 이전 갱신은 캐시 TTL 동안 남을 수 있습니다.
+// 앞서 전달한 WithInvalidateOnWrite를 취소합니다
+func WithVersionedWrite[V any](version func(*V) int64) Option {
+	return func(c *config) {
+		c.invalidate = false
+		c.version = func(v any) int64 {
+			return version(v.(*V))
+		}
+	}
+}
+
+// WithErrorHandler는 호출자에게 반환할 수 없는 캐시 갱신 에러를 받을 함수를 설정합니다
+//
+// 트랜잭션 커밋 후 실행되는 갱신과 백그라운드 flush의 에러가 전달됩니다
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// operation은 대기 중인 캐시 갱신입니다
+type operation[V any] struct {
+	value   V
+	version int64
+	delete  bool
+}
+
+// Coordinator는 Repository 쓰기와 캐시 갱신을 함께 수행합니다
+type Coordinator[K comparable, V any] struct {
+	repo  *postgres.Repository[V]
+	cache *cache.Cache[K, V]
+	key   func(*V) K
+	cfg   config
+
+	mu      sync.Mutex
+	pending map[K]operation[V]
+	order   []K
+	closed  bool
+
+	flushMu sync.Mutex
+	kick    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// New는 새로운 Coordinator를 생성합니다
+//
+// key는 entity에서 캐시 키를 꺼내는 함수이며, c의 Loader는 보통 RepositoryLoader(repo)입니다.
+// 캐시 갱신 방식으로 WithVersionedWrite나 WithInvalidateOnWrite 중 하나를 지정해야 하며,
+// 둘 다 없으면 ErrVersionRequired를 반환합니다.
+// write-behind 모드에서는 Close를 호출해 대기 중인 갱신을 반영하고 백그라운드 작업을 종료해야 합니다
+func New[K comparable, V any](repo *postgres.Repository[V], c *cache.Cache[K, V], key func(*V) K, opts ...Option) (*Coordinator[K, V], error) {
+	cfg := config{
+		mode:          WriteThrough,
+		flushInterval: DefaultFlushInterval,
+		batchSize:     DefaultBatchSize,
+		onError:       func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.version == nil && !cfg.invalidate {
+		return nil, ErrVersionRequired
+	}
+
+	co := &Coordinator[K, V]{
+		repo:    repo,
+		cache:   c,
+		key:     key,
+		cfg:     cfg,
+		pending: make(map[K]operation[V]),
+		kick:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	if cfg.mode == WriteBehind {
+		go co.run()
+	} else {
+		close(co.stopped)
+	}
+	return co, nil
+}
+
+// RepositoryLoader는 Repository.Get으로 값을 읽는 cache.Loader를 만듭니다
+func RepositoryLoader[K comparable, V any](repo *postgres.Repository[V]) cache.Loader[K, V] {
+	return func(ctx context.Context, id K) (V, error) {
+		entity, err := repo.Get(ctx, id)
+		if err != nil {
+			var zero V
+			return zero, err
+		}
+		return *entity, nil
+	}
+}
+
+// Get은 캐시를 거쳐 entity를 조회합니다
+func (co *Coordinator[K, V]) Get(ctx context.Context, id K) (V, error) {
+	return co.cache.Get(ctx, id)
+}
+
+// Insert는 entity를 추가하고 캐시에 반영합니다
+func (co *Coordinator[K, V]) Insert(ctx context.Context, entity *V) error {
+	if err := co.repo.Insert(ctx, entity); err != nil {
+		return err
+	}
+	return co.apply(ctx, co.key(entity), operation[V]{value: *entity})
+}
+
+// Update는 entity를 업데이트하고 캐시에 반영합니다
+func (co *Coordinator[K, V]) Update(ctx context.Context, entity *V) error {
+	if err := co.repo.Update(ctx, entity); err != nil {
+		return err
+	}
+	return co.apply(ctx, co.key(entity), operation[V]{value: *entity})
+}
+
+// Delete는 entity를 삭제하고 캐시 키를 무효화합니다
+func (co *Coordinator[K, V]) Delete(ctx context.Context, id K) error {
+	if err := co.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return co.apply(ctx, id, operation[V]{delete: true})
+}
+
+// Pending은 write-behind 큐에서 대기 중인 키 수를 반환합니다
+func (co *Coordinator[K, V]) Pending() int {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	return len(co.order)
+}
+
+// apply는 DB 쓰기가 성공한 뒤 캐시 갱신을 수행하거나 큐에 넣습니다
+//
+// ctx에 트랜잭션이 있으면 커밋된 뒤에 수행하고, 롤백되면 버립니다
+func (co *Coordinator[K, V]) apply(ctx context.Context, key K, op operation[V]) error {
+	if co.cfg.invalidate {
+		op = operation[V]{delete: true}
+	} else if !op.delete {
+		op.version = co.cfg.version(&op.value)
+	}
+
+	if _, inTx := postgres.TxFromContext(ctx); inTx {
+		postgres.AfterCommit(ctx, func(ctx context.Context) {
+			if err := co.dispatch(ctx, key, op); err != nil {
+				co.cfg.onError(err)
+			}
+		})
+		return nil
+	}
+	return co.dispatch(ctx, key, op)
+}
+
+// dispatch는 모드에 따라 캐시를 바로 갱신하거나 큐에 넣습니다
+//
+// Close 이후에는 큐에 넣을 수 없으므로 (예: Close 뒤에 커밋된 트랜잭션의 갱신) 캐시에 이전 값이
+// 남지 않도록 키를 바로 무효화합니다. 무효화는 그대로 반영되고, 값 갱신은 무효화한 뒤 ErrClosed를 반환합니다
+func (co *Coordinator[K, V]) dispatch(ctx context.Context, key K, op operation[V]) error {
+	if co.cfg.mode == WriteThrough {
+		return co.write(ctx, key, op)
+	}
+
+	err := co.enqueue(key, op)
+	if !errors.Is(err, ErrClosed) {
+		return err
+	}
+	if werr := co.write(ctx, key, operation[V]{delete: true}); werr != nil || !op.delete {
+		return errors.Join(err, werr)
+	}
+	return nil
+}
+
+// write는 캐시 갱신 하나를 반영합니다
+//
+// 캐시에 더 새로운 버전이 있으면 저장하지 않고, 저장에 실패하면 오래된 값이 남지 않도록
+// 키 무효화를 시도합니다
+func (co *Coordinator[K, V]) write(ctx context.Context, key K, op operation[V]) error {
+	var err error
+	if op.delete {
+		err = co.cache.Invalidate(ctx, key)
+	} else if _, err = co.cache.SetVersion(ctx, key, op.value, op.version); err != nil {
+		err = errors.Join(err, co.cache.Invalidate(ctx, key))
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w for key %v: %w", ErrCacheUpdate, key, err)
+	}
+	return nil
+}
+
+// enqueue는 갱신을 write-behind 큐에 넣습니다
+//
+// 같은 키의 갱신이 이미 대기 중이면 하나만 남깁니다. 값 갱신끼리는 버전이 더 높은 쪽을,
+// 무효화가 섞이면 마지막에 들어온 쪽을 남기므로, 커밋 순서와 다르게 도착한 무효화와 값
+// 갱신이 합쳐지면 flush 후에도 이전 값이 캐시 TTL 동안 남을 수 있습니다
+func (co *Coordinator[K, V]) enqueue(key K, op operation[V]) error {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	if co.closed {
+		return ErrClosed
+	}
+
+	prev, ok := co.pending[key]
+	if !ok {
+		co.order = append(co.order, key)
+	}
+	if ok && !prev.delete && !op.delete && prev.version > op.version {
+		return nil
+	}
+	co.pending[key] = op
+
+	if len(co.order) >= co.cfg.batchSize {
+		select {
+		case co.kick <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush는 대기 중인 캐시 갱신을 큐에 들어온 순서대로 반영합니다
+//
+// 실패한 갱신은 그 사이 같은 키에 새 갱신이 들어오지 않았다면 다시 큐에 넣습니다
+func (co *Coordinator[K, V]) Flush(ctx context.Context) error {
+	// 동시에 여러 flush가 실행되어 갱신 순서가 뒤바뀌지 않도록 직렬화
+	co.flushMu.Lock()
+	defer co.flushMu.Unlock()
+
+	co.mu.Lock()
+	batch, order := co.pending, co.order
+	co.pending, co.order = make(map[K]operation[V]), nil
+	co.mu.Unlock()
+
+	var errs []error
+	var deletes []K
+	for _, key := range order {
+		op := batch[key]
+		if op.delete {
+			deletes = append(deletes, key)
+			continue
+		}
+		if err := co.write(ctx, key, op); err != nil {
+			errs = append(errs, err)
+			co.requeue(key, op)
+		}
+	}
+
+	// 무효화는 한 번에 모아서 처리
+	if len(deletes) > 0 {
+		if err := co.cache.Invalidate(ctx, deletes...); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrCacheUpdate, err))
+			for _, key := range deletes {
+				co.requeue(key, batch[key])
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// requeue는 실패한 갱신을 다시 큐에 넣습니다 (더 새로운 갱신이 있으면 버림)
+func (co *Coordinator[K, V]) requeue(key K, op operation[V]) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	if _, ok := co.pending[key]; ok {
+		return
+	}
+	co.pending[key] = op
+	co.order = append(co.order, key)
+}
+
+// Close는 새 쓰기를 막고 대기 중인 갱신을 반영한 뒤 백그라운드 작업을 종료합니다
+func (co *Coordinator[K, V]) Close(ctx context.Context) error {
+	co.mu.Lock()
+	if co.closed {
+		co.mu.Unlock()
+		return nil
+	}
+	co.closed = true
+	co.mu.Unlock()
+
+	if co.cfg.mode == WriteBehind {
+		close(co.done)
+		select {
+		case <-co.stopped:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return co.Flush(ctx)
+}
+
+// run은 write-behind 큐를 주기적으로 반영합니다
+func (co *Coordinator[K, V]) run() {
+	defer close(co.stopped)
+
+	ticker := time.NewTicker(co.cfg.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-co.done:
+			return
+		case <-ticker.C:
+		case <-co.kick:
+		}
+
+		if err := co.Flush(context.Background()); err != nil {
+			co.cfg.onError(err)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package writecache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	pgModule "github.com/testcontainers/testcontainers-go/modules/postgres"
+	redisModule "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"testcontainers-learning/cache"
+	"testcontainers-learning/postgres"
+	"testcontainers-learning/redis"
+)
+
+var (
+	testPostgres *postgres.Client
+	testRedis    *redis.Client
+)
+
+// account는 users 테이블과 매핑되는 테스트용 entity입니다
+type account struct {
+	ID    int64  `db:"id,pk,auto" json:"id"`
+	Name  string `db:"name" json:"name"`
+	Email string `db:"email" json:"email"`
+}
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// PostgreSQL과 Redis 컨테이너 시작 (모든 테스트에서 공유)
+	postgresContainer, err := pgModule.Run(ctx,
+		"postgres:18-alpine",
+		pgModule.WithDatabase("testdb"),
+		pgModule.WithUsername("testuser"),
+		pgModule.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	redisContainer, err := redisModule.Run(ctx, "redis:7.2")
+	if err != nil {
+		_ = testcontainers.TerminateContainer(postgresContainer)
+		panic(err)
+	}
+
+	terminate := func() {
+		_ = testcontainers.TerminateContainer(redisContainer)
+		_ = testcontainers.TerminateContainer(postgresContainer)
+	}
+
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		terminate()
+		panic(err)
+	}
+	testPostgres, err = postgres.NewClient(connStr)
+	if err != nil {
+		terminate()
+		panic(err)
+	}
+
+	migrator, err := postgres.NewMigrator(testPostgres, postgres.SchemaMigrations())
+	if err == nil {
+		_, err = migrator.Up(ctx)
+	}
+	if err != nil {
+		testPostgres.Close()
+		terminate()
+		panic(err)
+	}
+
+	endpoint, err := redisContainer.Endpoint(ctx, "")
+	if err != nil {
+		testPostgres.Close()
+		terminate()
+		panic(err)
+	}
+	testRedis = redis.NewClient(endpoint)
+
+	// 테스트 실행
+	code := m.Run()
+
+	// 정리
+	testRedis.Close()
+	testPostgres.Close()
+	terminate()
+
+	os.Exit(code)
+}
+
+// flakyStore는 실패를 주입할 수 있는 cache.Store입니다
+type flakyStore struct {
+	cache.Store
+	failing atomic.Bool
+}
+
+func (s *flakyStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if s.failing.Load() {
+		return errors.New("store unavailable")
+	}
+	return s.Store.Set(ctx, key, value, ttl)
+}
+
+func (s *flakyStore) SetVersioned(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if s.failing.Load() {
+		return false, errors.New("store unavailable")
+	}
+	return s.Store.(cache.VersionedStore).SetVersioned(ctx, key, value, ttl)
+}
+
+// sequentialVersions는 쓰기마다 1씩 증가하는 버전으로 캐시 값을 덮어쓰는 Option입니다
+func sequentialVersions() Option {
+	var version atomic.Int64
+	return WithVersionedWrite(func(*account) int64 { return version.Add(1) })
+}
+
+// setupCoordinator는 호출 횟수를 세는 Loader로 캐시와 Coordinator를 만듭니다
+func setupCoordinator(t *testing.T, store cache.Store, prefix string, opts ...Option) (*Coordinator[int64, account], *atomic.Int32) {
+	repo, err := postgres.NewRepository[account](testPostgres, postgres.MustParseIdentifier("users"))
+	require.NoError(t, err)
+
+	var loads atomic.Int32
+	load := RepositoryLoader[int64](repo)
+	c := cache.New(store, func(ctx context.Context, id int64) (account, error) {
+		loads.Add(1)
+		return load(ctx, id)
+	},
+		cache.WithPrefix(prefix),
+		cache.WithNotFound(func(err error) bool { return errors.Is(err, postgres.ErrNotFound) }),
+	)
+
+	co, err := New(repo, c, func(a *account) int64 { return a.ID }, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { co.Close(context.Background()) })
+	return co, &loads
+}
+
+func TestNewRequiresWriteMode(t *testing.T) {
+	repo, err := postgres.NewRepository[account](nil, postgres.MustParseIdentifier("users"))
+	require.NoError(t, err)
+	c := cache.New(cache.NewRedisStore(testRedis), RepositoryLoader[int64](repo))
+	key := func(a *account) int64 { return a.ID }
+
+	// 행 버전 없이는 write-through로 값을 저장할 수 없음
+	_, err = New(repo, c, key)
+	assert.ErrorIs(t, err, ErrVersionRequired)
+	_, err = New(repo, c, key, WithWriteBehind(time.Hour, 10))
+	assert.ErrorIs(t, err, ErrVersionRequired)
+
+	co, err := New(repo, c, key, WithInvalidateOnWrite())
+	require.NoError(t, err)
+	require.NoError(t, co.Close(context.Background()))
+}
+
+func TestWriteThrough(t *testing.T) {
+	ctx := context.Background()
+	co, loads := setupCoordinator(t, cache.NewRedisStore(testRedis), "wt", sequentialVersions())
+
+	// Insert와 Update 후 캐시에 바로 반영되어 DB 조회 없음
+	acc := &account{Name: "Grace", Email: "grace.wt@example.com"}
+	require.NoError(t, co.Insert(ctx, acc))
+	acc.Name = "Grace Hopper"
+	require.NoError(t, co.Update(ctx, acc))
+
+	got, err := co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Grace Hopper", got.Name)
+	assert.Equal(t, int32(0), loads.Load())
+
+	// 먼저 커밋된 쓰기의 갱신이 늦게 도착해도 새 값을 덮어쓰지 않음
+	stale := *acc
+	stale.Name = "Grace (stale)"
+	require.NoError(t, co.write(ctx, acc.ID, operation[account]{value: stale, version: 1}))
+
+	got, err = co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Grace Hopper", got.Name)
+
+	// DB 쓰기가 실패하면 캐시는 그대로
+	other := &account{Name: "Jane", Email: "jane.wt@example.com"}
+	require.NoError(t, co.Insert(ctx, other))
+	other.Email = acc.Email
+	err = co.Update(ctx, other)
+	assert.ErrorIs(t, err, postgres.ErrDuplicate)
+
+	got, err = co.Get(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "jane.wt@example.com", got.Email)
+	assert.Equal(t, int32(0), loads.Load())
+
+	// Delete 후에는 캐시가 무효화되어 ErrNotFound
+	require.NoError(t, co.Delete(ctx, acc.ID))
+	_, err = co.Get(ctx, acc.ID)
+	assert.ErrorIs(t, err, cache.ErrNotFound)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestInvalidateOnWrite(t *testing.T) {
+	ctx := context.Background()
+	co, loads := setupCoordinator(t, cache.NewRedisStore(testRedis), "inv", WithInvalidateOnWrite())
+
+	// 쓰기 후 캐시를 무효화하므로 첫 조회는 DB에서 읽음
+	acc := &account{Name: "John Doe", Email: "john.inv@example.com"}
+	require.NoError(t, co.Insert(ctx, acc))
+	require.NotZero(t, acc.ID)
+
+	for range 2 {
+		got, err := co.Get(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *acc, got)
+	}
+	assert.Equal(t, int32(1), loads.Load())
+
+	// Update 후에도 캐시된 이전 값 대신 DB에서 다시 읽음
+	acc.Name = "Johnny"
+	require.NoError(t, co.Update(ctx, acc))
+
+	got, err := co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Johnny", got.Name)
+	assert.Equal(t, int32(2), loads.Load())
+
+	// Delete 후에는 ErrNotFound
+	require.NoError(t, co.Delete(ctx, acc.ID))
+	_, err = co.Get(ctx, acc.ID)
+	assert.ErrorIs(t, err, cache.ErrNotFound)
+	assert.Equal(t, int32(3), loads.Load())
+}
+
+func TestWriteThroughTransaction(t *testing.T) {
+	ctx := context.Background()
+	co, loads := setupCoordinator(t, cache.NewRedisStore(testRedis), "wt-tx", sequentialVersions())
+
+	acc := &account{Name: "Alice", Email: "alice.tx@example.com"}
+	require.NoError(t, co.Insert(ctx, acc))
+
+	// 롤백된 트랜잭션의 쓰기는 캐시에 반영되지 않음
+	errAbort := errors.New("abort")
+	err := testPostgres.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		updated := *acc
+		updated.Name = "Alice (rolled back)"
+		if err := co.Update(ctx, &updated); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	got, err := co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", got.Name)
+
+	// 커밋 전에는 캐시가 바뀌지 않고, 커밋 후 반영
+	err = testPostgres.ExecuteInTransactionWithOptions(ctx, nil, func(ctx context.Context, tx *sqlx.Tx) error {
+		updated := *acc
+		updated.Name = "Alice Kim"
+		if err := co.Update(ctx, &updated); err != nil {
+			return err
+		}
+
+		got, err := co.Get(context.Background(), acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", got.Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	got, err = co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Kim", got.Name)
+	assert.Equal(t, int32(0), loads.Load())
+}
+
+func TestWriteThroughCacheFailure(t *testing.T) {
+	ctx := context.Background()
+	store := &flakyStore{Store: cache.NewRedisStore(testRedis)}
+	co, loads := setupCoordinator(t, store, "wt-fail", sequentialVersions())
+
+	acc := &account{Name: "Bob", Email: "bob.fail@example.com"}
+	require.NoError(t, co.Insert(ctx, acc))
+
+	// 캐시 저장이 실패해도 DB 쓰기는 유지되고 캐시 키는 무효화됨
+	store.failing.Store(true)
+	acc.Name = "Bobby"
+	err := co.Update(ctx, acc)
+	assert.ErrorIs(t, err, ErrCacheUpdate)
+
+	store.failing.Store(false)
+	got, err := co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Bobby", got.Name)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestWriteBehind(t *testing.T) {
+	ctx := context.Background()
+	co, loads := setupCoordinator(t, cache.NewRedisStore(testRedis), "wb", WithWriteBehind(time.Hour, 100), sequentialVersions())
+
+	acc := &account{Name: "Carol", Email: "carol.wb@example.com"}
+	require.NoError(t, co.Insert(ctx, acc))
+
+	// 같은 키의 갱신은 하나로 합쳐짐
+	for _, name := range []string{"Carol Lee", "Carol Park", "Carol Choi"} {
+		acc.Name = name
+		require.NoError(t, co.Update(ctx, acc))
+	}
+	assert.Equal(t, 1, co.Pending())
+
+	// 늦게 도착한 이전 버전의 갱신은 대기 중인 새 갱신을 대체하지 않음
+	stale := *acc
+	stale.Name = "Carol (stale)"
+	require.NoError(t, co.enqueue(acc.ID, operation[account]{value: stale, version: 1}))
+	assert.Equal(t, 1, co.Pending())
+
+	// flush 전에는 캐시에 값이 없어 DB에서 읽음
+	_, err := co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), loads.Load())
+
+	// flush 후에는 마지막 값이 캐시에 반영
+	require.NoError(t, co.Flush(ctx))
+	assert.Equal(t, 0, co.Pending())
+
+	got, err := co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Carol Choi", got.Name)
+
+	// 삭제도 큐를 거쳐 반영
+	require.NoError(t, co.Delete(ctx, acc.ID))
+	got, err = co.Get(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Carol Choi", got.Name)
+
+	require.NoError(t, co.Close(ctx))
+	_, err = co.Get(ctx, acc.ID)
+	assert.ErrorIs(t, err, cache.ErrNotFound)
+
+	// Close 이후 쓰기는 거부
+	err = co.Insert(ctx, &account{Name: "Late", Email: "late.wb@example.com"})
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestWriteBehindCommitAfterClose(t *testing.T) {
+	ctx := context.Background()
+
+	var closedErrors atomic.Int32
+	co, loads := setupCoordinator(t, cache.NewRedisStore(testRedis), "wb-closed",
+		WithWriteBehind(time.Hour, 100),
+		sequentialVersions(),
+		WithErrorHandler(func(err error) {
+			assert.ErrorIs(t, err, ErrClosed)
+			closedErrors.Add(1)
+		}),
+	)
+
+	kept := &account{Name: "Frank", Email: "frank.closed@example.com"}
+	removed := &account{Name: "Grace", Email: "grace.closed@example.com"}
+	require.NoError(t, co.Insert(ctx, kept))
+	require.NoError(t, co.Insert(ctx, removed))
+	require.NoError(t, co.Flush(ctx))
+
+	// 트랜잭션이 Close 이후에 커밋되면 큐 대신 바로 캐시 키를 무효화
+	err := testPostgres.ExecuteInTransaction(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		updated := *kept
+		updated.Name = "Frank Lee"
+		if err := co.Update(ctx, &updated); err != nil {
+			return err
+		}
+		if err := co.Delete(ctx, removed.ID); err != nil {
+			return err
+		}
+		return co.Close(ctx)
+	})
+	require.NoError(t, err)
+
+	// 삭제는 무효화로 그대로 반영되고, 값 갱신은 무효화 후 ErrClosed를 알림
+	_, err = co.Get(ctx, removed.ID)
+	assert.ErrorIs(t, err, cache.ErrNotFound)
+	got, err := co.Get(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Frank Lee", got.Name)
+	assert.Equal(t, int32(2), loads.Load())
+	assert.Equal(t, int32(1), closedErrors.Load())
+}
+
+func TestWriteBehindBatchAndRetry(t *testing.T) {
+	ctx := context.Background()
+	store := &flakyStore{Store: cache.NewRedisStore(testRedis)}
+
+	var flushErrors atomic.Int32
+	co, loads := setupCoordinator(t, store, "wb-batch",
+		WithWriteBehind(time.Hour, 2),
+		sequentialVersions(),
+		WithErrorHandler(func(err error) {
+			assert.ErrorIs(t, err, ErrCacheUpdate)
+			flushErrors.Add(1)
+		}),
+	)
+
+	// 실패한 갱신은 다시 큐에 들어감
+	store.failing.Store(true)
+	first := &account{Name: "Dave", Email: "dave.wb@example.com"}
+	require.NoError(t, co.Insert(ctx, first))
+
+	err := co.Flush(ctx)
+	assert.ErrorIs(t, err, ErrCacheUpdate)
+	assert.Equal(t, 1, co.Pending())
+	store.failing.Store(false)
+
+	// batchSize에 도달하면 백그라운드에서 바로 반영
+	second := &account{Name: "Eve", Email: "eve.wb@example.com"}
+	require.NoError(t, co.Insert(ctx, second))
+	require.Eventually(t, func() bool { return co.Pending() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	for _, acc := range []*account{first, second} {
+		got, err := co.Get(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, acc.Name, got.Name)
+	}
+	assert.Equal(t, int32(0), loads.Load())
+	assert.Equal(t, int32(0), flushErrors.Load())
+}